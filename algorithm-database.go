@@ -0,0 +1,305 @@
+package rubiks_cube
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./cmd/genalgs -o algorithms
+
+//go:embed algorithms/*.json
+var algorithmFiles embed.FS
+
+var (
+	ErrCaseNotFound       = errors.New("no case matches the cube")
+	ErrDuplicateCase      = errors.New("duplicate case")
+	ErrInvalidCasePattern = errors.New("invalid case pattern")
+)
+
+// AlgorithmSet names a set of last layer algorithms.
+type AlgorithmSet string
+
+const (
+	// OLLSet orients the last layer.
+	OLLSet AlgorithmSet = "OLL"
+
+	// PLLSet permutes the last layer once it is oriented.
+	PLLSet AlgorithmSet = "PLL"
+
+	// COLLSet solves the corners of the last layer once its edges are
+	// oriented, keeping the edges oriented.
+	COLLSet AlgorithmSet = "COLL"
+
+	// ZBLLSet solves the last layer once its edges are oriented. The cases
+	// with every corner oriented are left to PLLSet.
+	ZBLLSet AlgorithmSet = "ZBLL"
+)
+
+// lastLayerPatternLength is the length of RubiksCube.LastLayerPattern.
+const lastLayerPatternLength = 21
+
+// faceLetters holds the letter of each face as used by moves.
+const faceLetters = "UDFBRL"
+
+// LastLayerPattern describes the last layer, which is the U layer as the cube
+// is held. Each facelet is written as the letter of the face with a center of
+// its color: first the nine facelets of the U face in the order of Face, then
+// the top three facelets of the F, R, B and L faces.
+func (r RubiksCube) LastLayerPattern() string {
+	var letters [6]byte
+	for f := FaceUp; f <= FaceLeft; f++ {
+		letters[r.Face(f)[4]] = faceLetters[f]
+	}
+	b := make([]byte, 0, lastLayerPatternLength)
+	for _, c := range r.Face(FaceUp) {
+		b = append(b, letters[c])
+	}
+	for _, f := range []Face{FaceFront, FaceRight, FaceBack, FaceLeft} {
+		face := r.Face(f)
+		for _, c := range face[:3] {
+			b = append(b, letters[c])
+		}
+	}
+	return string(b)
+}
+
+// Pattern returns the last layer pattern of the cube, see
+// RubiksCube.LastLayerPattern, with a '.' for each facelet which does not
+// matter to the set. OLLSet only keeps the U facelets and COLLSet hides the
+// side facelets of the edges.
+func (s AlgorithmSet) Pattern(cube RubiksCube) string {
+	p := []byte(cube.LastLayerPattern())
+	for i, c := range p {
+		switch {
+		case s == OLLSet && c != 'U', s == COLLSet && i >= 9 && i%3 == 1:
+			p[i] = '.'
+		}
+	}
+	return string(p)
+}
+
+// CaseAlgorithm is one of the algorithms which solves a case.
+type CaseAlgorithm struct {
+	// AUF turns the U layer before Algorithm when the cube matches the
+	// pattern of the case.
+	AUF Algorithm `json:"auf"`
+
+	// Algorithm solves the case.
+	Algorithm Algorithm `json:"algorithm"`
+
+	// Generated is true if the algorithm was found by a computer search
+	// rather than written by hand. These are short but often awkward to
+	// perform.
+	Generated bool `json:"generated,omitempty"`
+}
+
+// AlgorithmCase is a case of the last layer and the algorithms which solve it.
+//
+// Starting from a cube with the first two layers solved whose pattern of the
+// set is Pattern, the AUF and algorithm of every entry in Algorithms reach the
+// goal of the set: the last layer oriented for OLLSet, its corners solved with
+// the edges oriented for COLLSet or the cube solved for PLLSet and ZBLLSet.
+type AlgorithmCase struct {
+	// Set is the set holding the case.
+	Set AlgorithmSet `json:"-"`
+
+	// ID names the case uniquely, like "OLL 27", "T-perm" or "ZBLL T1".
+	ID string `json:"id"`
+
+	// Name is the common name of the case, if it has one.
+	Name string `json:"name,omitempty"`
+
+	// Pattern is the pattern of the case, see AlgorithmSet.Pattern.
+	Pattern string `json:"pattern"`
+
+	// Tags group the case with similar cases, like "dot" or "adjacent swap".
+	Tags []string `json:"tags,omitempty"`
+
+	// Algorithms solve the case and the first is written by hand and never
+	// needs an AUF. The first of a ZBLLSet case is the first algorithm of
+	// its COLLSet case followed by a PLL which only moves the edges. The
+	// others are sorted shortest first.
+	Algorithms []CaseAlgorithm `json:"algorithms"`
+}
+
+// algorithmCasesFile is the layout of the JSON files holding the cases of a
+// set.
+type algorithmCasesFile struct {
+	Set   AlgorithmSet    `json:"set"`
+	Cases []AlgorithmCase `json:"cases"`
+}
+
+// ReadAlgorithmCases reads the cases of a set written by WriteAlgorithmCases.
+func ReadAlgorithmCases(r io.Reader) ([]AlgorithmCase, error) {
+	var f algorithmCasesFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	for i := range f.Cases {
+		f.Cases[i].Set = f.Set
+	}
+	return f.Cases, nil
+}
+
+// WriteAlgorithmCases writes the cases of a set as JSON.
+func WriteAlgorithmCases(w io.Writer, set AlgorithmSet, cases []AlgorithmCase) error {
+	b, err := json.MarshalIndent(algorithmCasesFile{Set: set, Cases: cases}, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// AlgorithmDatabase holds the cases of several sets and finds them by ID or
+// by the state of a cube.
+type AlgorithmDatabase struct {
+	cases    []AlgorithmCase
+	ids      map[string]int
+	patterns map[AlgorithmSet]map[string]int
+}
+
+// NewAlgorithmDatabase makes a database of the cases. ErrDuplicateCase is
+// returned if two cases share an ID or the same set and pattern.
+func NewAlgorithmDatabase(cases []AlgorithmCase) (*AlgorithmDatabase, error) {
+	d := &AlgorithmDatabase{
+		cases:    cases,
+		ids:      make(map[string]int),
+		patterns: make(map[AlgorithmSet]map[string]int),
+	}
+	for i, c := range cases {
+		if len(c.Pattern) != lastLayerPatternLength {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCasePattern, c.ID)
+		}
+		if _, found := d.ids[c.ID]; found {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCase, c.ID)
+		}
+		d.ids[c.ID] = i
+		if d.patterns[c.Set] == nil {
+			d.patterns[c.Set] = make(map[string]int)
+		}
+		if _, found := d.patterns[c.Set][c.Pattern]; found {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCase, c.ID)
+		}
+		d.patterns[c.Set][c.Pattern] = i
+	}
+	return d, nil
+}
+
+// LoadAlgorithmDatabase reads every JSON file in the root of fsys with
+// ReadAlgorithmCases into one database.
+func LoadAlgorithmDatabase(fsys fs.FS) (*AlgorithmDatabase, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	var cases []AlgorithmCase
+	for _, name := range names {
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		c, err := ReadAlgorithmCases(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		cases = append(cases, c...)
+	}
+	return NewAlgorithmDatabase(cases)
+}
+
+var (
+	defaultAlgorithmsOnce sync.Once
+	defaultAlgorithms     *AlgorithmDatabase
+)
+
+// DefaultAlgorithmDatabase returns the database built into the package, which
+// holds the 57 OLL, 21 PLL, 42 COLL and 472 ZBLL cases. Every algorithm is
+// checked by the tests. It is safe to call from multiple goroutines.
+func DefaultAlgorithmDatabase() *AlgorithmDatabase {
+	defaultAlgorithmsOnce.Do(func() {
+		sub, err := fs.Sub(algorithmFiles, "algorithms")
+		if err == nil {
+			defaultAlgorithms, err = LoadAlgorithmDatabase(sub)
+		}
+		if err != nil {
+			panic(err)
+		}
+	})
+	return defaultAlgorithms
+}
+
+// Case returns the case with the ID.
+func (d *AlgorithmDatabase) Case(id string) (AlgorithmCase, bool) {
+	i, found := d.ids[id]
+	if !found {
+		return AlgorithmCase{}, false
+	}
+	return d.cases[i], true
+}
+
+// Cases returns every case of the set in the order they were added.
+func (d *AlgorithmDatabase) Cases(set AlgorithmSet) []AlgorithmCase {
+	var z []AlgorithmCase
+	for _, c := range d.cases {
+		if c.Set == set {
+			z = append(z, c)
+		}
+	}
+	return z
+}
+
+// CaseMatch is a case found by AlgorithmDatabase.FindCase.
+type CaseMatch struct {
+	Case AlgorithmCase
+
+	// AUF turns the U layer so the cube matches the pattern of the case.
+	AUF Algorithm
+
+	// PostAUF turns the U layer after any algorithm of the case to line the
+	// last layer up with the first two layers. It is always empty for
+	// OLLSet.
+	PostAUF Algorithm
+}
+
+// FindCase finds the case of the set matching the cube after turning the U
+// layer, with the last layer as the U layer as the cube is held. The last
+// layer may also be turned against the first two layers. The cube is checked
+// with RubiksCube.Validate first and ErrF2LUnsolved is returned if the first
+// two layers are not solved.
+func (d *AlgorithmDatabase) FindCase(set AlgorithmSet, cube RubiksCube) (CaseMatch, error) {
+	if err := checkLastLayer(cube); err != nil {
+		return CaseMatch{}, err
+	}
+	patterns := d.patterns[set]
+	for _, auf := range upTurns {
+		p := set.Pattern(cube.Apply(auf))
+		for n, post := range upTurns {
+			if i, found := patterns[shiftSides(p, n)]; found {
+				return CaseMatch{Case: d.cases[i], AUF: auf, PostAUF: post}, nil
+			}
+		}
+	}
+	return CaseMatch{}, ErrCaseNotFound
+}
+
+// shiftSides changes the letter of each side facelet of the pattern to the
+// next side n times, going F, R, B, L, which matches a last layer turned with
+// the n-th of upTurns after it is solved.
+func shiftSides(p string, n int) string {
+	const sides = "FRBL"
+	b := []byte(p)
+	for i, c := range b {
+		if j := strings.IndexByte(sides, c); j >= 0 {
+			b[i] = sides[(j+n)%4]
+		}
+	}
+	return string(b)
+}
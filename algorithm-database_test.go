@@ -0,0 +1,138 @@
+package rubiks_cube
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDefaultAlgorithmDatabase(t *testing.T) {
+	d := DefaultAlgorithmDatabase()
+	solved := NewSolvedCube()
+	for set, n := range map[AlgorithmSet]int{OLLSet: 57, PLLSet: 21, COLLSet: 42, ZBLLSet: 472} {
+		cases := d.Cases(set)
+		assert.Len(t, cases, n, set)
+		for _, c := range cases {
+			assert.Equal(t, set, c.Set)
+			assert.NotEmpty(t, c.Tags, c.ID)
+			if !assert.NotEmpty(t, c.Algorithms, c.ID) {
+				continue
+			}
+			assert.Empty(t, c.Algorithms[0].AUF, c.ID)
+			assert.False(t, c.Algorithms[0].Generated, c.ID)
+
+			// every algorithm solves a cube matching the pattern and turns
+			// the cube differently from the others
+			seen := make(map[string]bool)
+			for _, a := range c.Algorithms {
+				moves := a.AUF.Concat(a.Algorithm).SimplifyAcrossRotations().String()
+				assert.False(t, seen[moves], "%s: %s", c.ID, moves)
+				seen[moves] = true
+
+				start := solved.Apply(a.AUF.Concat(a.Algorithm).Inverse())
+				assert.True(t, start.IsF2LSolved(), "%s: %s", c.ID, a.Algorithm)
+				assert.Equal(t, c.Pattern, set.Pattern(start), "%s: %s", c.ID, a.Algorithm)
+			}
+
+			// the case is found whichever way the last layer is turned
+			a := c.Algorithms[len(c.Algorithms)-1]
+			for _, after := range upTurns {
+				for _, before := range upTurns {
+					cube := solved.Apply(after.Inverse().Concat(c.Algorithms[0].Algorithm.Inverse(), before.Inverse()))
+					m, err := d.FindCase(set, cube)
+					if !assert.NoError(t, err, c.ID) {
+						continue
+					}
+					assert.Equal(t, c.ID, m.Case.ID)
+					assert.Equal(t, set.Pattern(solved), set.Pattern(cube.Apply(m.AUF.Concat(a.AUF, a.Algorithm, m.PostAUF))), c.ID)
+				}
+			}
+		}
+	}
+}
+
+func TestRubiksCube_LastLayerPattern(t *testing.T) {
+	cube := NewSolvedCube()
+	assert.Equal(t, "UUUUUUUUUFFFRRRBBBLLL", cube.LastLayerPattern())
+	assert.Equal(t, "UUUUUUUUUFFFRRRBBBLLL", cube.Apply(Algorithm{RotationY}).LastLayerPattern())
+	assert.Equal(t, "UUUUUUUUURRRBBBLLLFFF", cube.Apply(Algorithm{Up}).LastLayerPattern())
+	assert.Equal(t, "UUUUUUUUURRRBBBLLLFFF", cube.Apply(Algorithm{Up, RotationY}).LastLayerPattern())
+	assert.Equal(t, "UUUUUUUUU.........", OLLSet.Pattern(cube)[:18])
+	assert.Equal(t, "UUUUUUUUUF.FR.RB.BL.L", COLLSet.Pattern(cube))
+}
+
+func TestDefaultAlgorithmDatabase_Tags(t *testing.T) {
+	d := DefaultAlgorithmDatabase()
+	for _, c := range d.Cases(OLLSet) {
+		edges := strings.Count(c.Pattern[1:2]+c.Pattern[3:4]+c.Pattern[5:6]+c.Pattern[7:8], "U")
+		corners := strings.Count(c.Pattern[0:1]+c.Pattern[2:3]+c.Pattern[6:7]+c.Pattern[8:9], "U")
+		switch c.Tags[0] {
+		case "cross":
+			assert.Equal(t, 4, edges, c.ID)
+		case "dot":
+			assert.Equal(t, 0, edges, c.ID)
+		case "corners oriented":
+			assert.Equal(t, 4, corners, c.ID)
+		}
+	}
+
+	// COLL and ZBLL cases are tagged with the shape of their corners
+	shapes := map[string]string{"OLL 21": "H", "OLL 22": "Pi", "OLL 23": "U", "OLL 24": "T", "OLL 25": "L", "OLL 26": "Antisune", "OLL 27": "Sune"}
+	for _, set := range []AlgorithmSet{COLLSet, ZBLLSet} {
+		for _, c := range d.Cases(set) {
+			start := NewSolvedCube().Apply(c.Algorithms[0].Algorithm.Inverse())
+			m, err := d.FindCase(OLLSet, start)
+			if err != nil {
+				assert.Equal(t, []string{"corners oriented"}, c.Tags, c.ID)
+				continue
+			}
+			assert.Equal(t, shapes[m.Case.ID], c.Tags[0], c.ID)
+		}
+	}
+}
+
+func TestAlgorithmDatabase_Case(t *testing.T) {
+	d := DefaultAlgorithmDatabase()
+	c, found := d.Case("OLL 27")
+	assert.True(t, found)
+	assert.Equal(t, "Sune", c.Name)
+	assert.Equal(t, "R U R' U R U2 R'", c.Algorithms[0].Algorithm.String())
+	c, found = d.Case("T-perm")
+	assert.True(t, found)
+	assert.Equal(t, PLLSet, c.Set)
+	assert.Equal(t, []string{"adjacent swap"}, c.Tags)
+	_, found = d.Case("ZBLL T1")
+	assert.True(t, found)
+	_, found = d.Case("OLL 58")
+	assert.False(t, found)
+}
+
+func TestAlgorithmDatabase_FindCase(t *testing.T) {
+	d := DefaultAlgorithmDatabase()
+	m, err := d.FindCase(PLLSet, NewSolvedCube().Apply(mustParseAlgorithm(t, "U R U R' U' R' F R2 U' R' U' R U R' F'")))
+	assert.NoError(t, err)
+	assert.Equal(t, "T-perm", m.Case.ID)
+	assert.Empty(t, m.AUF)
+	assert.Equal(t, Algorithm{UpPrime}, m.PostAUF)
+
+	// ZBLL needs the edges to be oriented
+	_, err = d.FindCase(ZBLLSet, NewSolvedCube().Apply(mustParseAlgorithm(t, "F R U R' U' F'")))
+	assert.ErrorIs(t, err, ErrCaseNotFound)
+	_, err = d.FindCase(OLLSet, NewSolvedCube().Apply(Algorithm{Right}))
+	assert.ErrorIs(t, err, ErrF2LUnsolved)
+}
+
+func TestAlgorithmCases_ReadWrite(t *testing.T) {
+	cases := DefaultAlgorithmDatabase().Cases(PLLSet)
+	var b bytes.Buffer
+	assert.NoError(t, WriteAlgorithmCases(&b, PLLSet, cases))
+	read, err := ReadAlgorithmCases(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, cases, read)
+
+	_, err = NewAlgorithmDatabase(append(cases, cases[0]))
+	assert.ErrorIs(t, err, ErrDuplicateCase)
+	_, err = NewAlgorithmDatabase([]AlgorithmCase{{ID: "short", Pattern: "UUU"}})
+	assert.ErrorIs(t, err, ErrInvalidCasePattern)
+}
@@ -0,0 +1,133 @@
+package rubiks_cube
+
+import (
+	"strings"
+)
+
+// Algorithm is a sequence of moves which are applied in order.
+type Algorithm []Move
+
+// Apply applies every move of the algorithm a to the cube in order.
+func (r RubiksCube) Apply(a Algorithm) RubiksCube {
+	for _, m := range a {
+		r = r.Move(m)
+	}
+	return r
+}
+
+// Inverse returns the algorithm which undoes a.
+func (a Algorithm) Inverse() Algorithm {
+	z := make(Algorithm, len(a))
+	for i, m := range a {
+		z[len(a)-1-i] = m.Reverse()
+	}
+	return z
+}
+
+// Mirror returns the algorithm reflected from left to right, so the right face
+// is swapped with the left face and every move turns the other way.
+func (a Algorithm) Mirror() Algorithm {
+	return a.mirror(TurnOfRightLeft)
+}
+
+// MirrorFrontBack returns the algorithm reflected from front to back, so the
+// front face is swapped with the back face and every move turns the other way.
+func (a Algorithm) MirrorFrontBack() Algorithm {
+	return a.mirror(TurnOfFrontBack)
+}
+
+func (a Algorithm) mirror(axis TurnOfCubelet) Algorithm {
+	z := make(Algorithm, len(a))
+	for i, m := range a {
+		z[i] = m.mirror(axis)
+	}
+	return z
+}
+
+// Concat returns a new algorithm with the moves of a followed by the moves of
+// each algorithm in b.
+func (a Algorithm) Concat(b ...Algorithm) Algorithm {
+	n := len(a)
+	for _, i := range b {
+		n += len(i)
+	}
+	z := make(Algorithm, 0, n)
+	z = append(z, a...)
+	for _, i := range b {
+		z = append(z, i...)
+	}
+	return z
+}
+
+// String returns the algorithm in WCA notation, which can be read back using
+// ParseAlgorithm.
+func (a Algorithm) String() string {
+	var s strings.Builder
+	for i, m := range a {
+		if i != 0 {
+			s.WriteByte(' ')
+		}
+		s.WriteString(m.Notation())
+	}
+	return s.String()
+}
+
+// MarshalText writes the algorithm as String does.
+func (a Algorithm) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// HTM returns the length of the algorithm in the half turn metric. Face and
+// wide turns count as one move, slice turns count as two moves and rotations
+// are not counted.
+func (a Algorithm) HTM() int {
+	n := 0
+	for _, m := range a {
+		switch {
+		case m.Rotation():
+		case m.Slice():
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// QTM returns the length of the algorithm in the quarter turn metric. This is
+// the same as HTM except half turns count twice.
+func (a Algorithm) QTM() int {
+	n := 0
+	for _, m := range a {
+		k := 1
+		if m.Double() {
+			k = 2
+		}
+		switch {
+		case m.Rotation():
+		case m.Slice():
+			n += 2 * k
+		default:
+			n += k
+		}
+	}
+	return n
+}
+
+// STM returns the length of the algorithm in the slice turn metric where every
+// move except rotations counts as one move.
+func (a Algorithm) STM() int {
+	n := 0
+	for _, m := range a {
+		if !m.Rotation() {
+			n++
+		}
+	}
+	return n
+}
+
+// ETM returns the length of the algorithm in the execution turn metric where
+// every move including rotations counts as one move.
+func (a Algorithm) ETM() int {
+	return len(a)
+}
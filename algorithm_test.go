@@ -0,0 +1,105 @@
+package rubiks_cube
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func mustParseAlgorithm(t *testing.T, v string) Algorithm {
+	a, err := ParseAlgorithm(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestAlgorithm_Inverse(t *testing.T) {
+	a := mustParseAlgorithm(t, "R U2 F' M E2 S' x y2 z' Rw u' Fw2")
+	assert.Equal(t, "Fw2 Uw Rw' z y2 x' S E2 M' F U2 R'", a.Inverse().String())
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "D L2 B'"))
+	assert.Equal(t, cube, cube.Apply(a).Apply(a.Inverse()))
+	assert.Equal(t, cube, cube.Apply(a.Inverse()).Apply(a))
+}
+
+func TestAlgorithm_Mirror(t *testing.T) {
+	sune := mustParseAlgorithm(t, "R U R' U R U2 R'")
+	assert.Equal(t, "L' U' L U' L' U2 L", sune.Mirror().String())
+	assert.Equal(t, "R' U' R U' R' U2 R", sune.MirrorFrontBack().String())
+	a := mustParseAlgorithm(t, "F B' M E S x y z r u f")
+	assert.Equal(t, "F' B M E' S' x y' z' Lw' Uw' Fw'", a.Mirror().String())
+	assert.Equal(t, "B' F M' E' S x' y' z Rw' Uw' Bw'", a.MirrorFrontBack().String())
+	assert.Equal(t, a, a.Mirror().Mirror())
+	assert.Equal(t, a, a.MirrorFrontBack().MirrorFrontBack())
+}
+
+func TestAlgorithm_Mirror_Cube(t *testing.T) {
+	// a mirrored algorithm applied to a solved cube gives the mirror image of
+	// the original state, so the front face is mirrored left to right
+	a := mustParseAlgorithm(t, "R U F' M2 r")
+	cube := NewSolvedCube().Apply(a).Face(FaceFront)
+	mirror := NewSolvedCube().Apply(a.Mirror()).Face(FaceFront)
+	swap := func(c Color) Color {
+		switch c {
+		case Green:
+			return Blue
+		case Blue:
+			return Green
+		}
+		return c
+	}
+	for i := 0; i < 9; i += 3 {
+		assert.Equal(t, swap(cube[i]), mirror[i+2])
+		assert.Equal(t, swap(cube[i+1]), mirror[i+1])
+		assert.Equal(t, swap(cube[i+2]), mirror[i])
+	}
+}
+
+func TestAlgorithm_Concat(t *testing.T) {
+	a := mustParseAlgorithm(t, "R U")
+	b := mustParseAlgorithm(t, "F")
+	c := a.Concat(b, a)
+	assert.Equal(t, "R U F R U", c.String())
+	c[0] = Left
+	assert.Equal(t, Right, a[0])
+}
+
+func TestAlgorithm_String(t *testing.T) {
+	for i := Up; i <= LeftWide2; i++ {
+		a, err := ParseAlgorithm(Algorithm{i}.String())
+		assert.NoError(t, err)
+		assert.Equal(t, Algorithm{i}, a)
+	}
+	assert.Equal(t, "", Algorithm{}.String())
+}
+
+func TestAlgorithm_Metrics(t *testing.T) {
+	for _, i := range []struct {
+		alg                string
+		htm, qtm, stm, etm int
+	}{
+		{"R U R' U'", 4, 4, 4, 4},
+		{"R2 U2", 2, 4, 2, 2},
+		{"M' U M", 5, 5, 3, 3},
+		{"M2 U2 M2", 5, 10, 3, 3},
+		{"x R y' U2", 2, 3, 2, 4},
+		{"r U' r2", 3, 4, 3, 3},
+	} {
+		a := mustParseAlgorithm(t, i.alg)
+		assert.Equal(t, i.htm, a.HTM(), i.alg)
+		assert.Equal(t, i.qtm, a.QTM(), i.alg)
+		assert.Equal(t, i.stm, a.STM(), i.alg)
+		assert.Equal(t, i.etm, a.ETM(), i.alg)
+	}
+}
+
+func TestAlgorithm_MarshalText(t *testing.T) {
+	a := mustParseAlgorithm(t, "R U R' U' r2 M'")
+	b, err := json.Marshal(a)
+	assert.NoError(t, err)
+	assert.Equal(t, `"R U R' U' Rw2 M'"`, string(b))
+	var z Algorithm
+	assert.NoError(t, json.Unmarshal(b, &z))
+	assert.Equal(t, a, z)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"R Q"`), &z), ErrInvalidMove)
+}
@@ -0,0 +1,390 @@
+package rubiks_cube
+
+// beginnerSides are the side faces in the order a clockwise U turn moves them
+// to the front. Frame k is the cube held with beginnerSides[k] at the front.
+var beginnerSides = [4]Face{FaceFront, FaceRight, FaceBack, FaceLeft}
+
+// The slots of each frame which are named from the front of that frame, so
+// frameMiddleEdges[k] is the edge between the front and the right face when
+// the cube is held in frame k.
+var (
+	frameUpEdges     = [4]EdgeSlot{SlotUF, SlotUR, SlotUB, SlotUL}
+	frameDownEdges   = [4]EdgeSlot{SlotDF, SlotDR, SlotDB, SlotDL}
+	frameMiddleEdges = [4]EdgeSlot{SlotFR, SlotBR, SlotBL, SlotFL}
+	frameUpCorners   = [4]CornerSlot{SlotUFR, SlotUBR, SlotUBL, SlotUFL}
+	frameDownCorners = [4]CornerSlot{SlotDFR, SlotDBR, SlotDBL, SlotDFL}
+)
+
+// The algorithms of the beginner method, written for the cube held in frame 0.
+var (
+	// U' R' F R
+	beginnerCrossFlip = Algorithm{UpPrime, RightPrime, Front, Right}
+	// R U R' U'
+	beginnerCornerTrigger = Algorithm{Right, Up, RightPrime, UpPrime}
+	// U R U' R' U' F' U F
+	beginnerRightInsert = Algorithm{Up, Right, UpPrime, RightPrime, UpPrime, FrontPrime, Up, Front}
+	// U' L' U L U F U' F'
+	beginnerLeftInsert = Algorithm{UpPrime, LeftPrime, Up, Left, Up, Front, UpPrime, FrontPrime}
+	// F R U R' U' F'
+	beginnerYellowCross = Algorithm{Front, Right, Up, RightPrime, UpPrime, FrontPrime}
+	// R U R' U R U2 R' U
+	beginnerEdgeSwap = Algorithm{Right, Up, RightPrime, Up, Right, Up2, RightPrime, Up}
+	// U R U' L' U R' U' L
+	beginnerCornerCycle = Algorithm{Up, Right, UpPrime, LeftPrime, Up, RightPrime, UpPrime, Left}
+	// R' D' R D
+	beginnerCornerTwist = Algorithm{RightPrime, DownPrime, Right, Down}
+)
+
+// SolveBeginner solves the cube the way the layer by layer beginner method is
+// taught. The cube is turned to hold white on the bottom, then the solution has
+// a segment for each stage:
+//
+//	White cross
+//	First layer corners
+//	Second layer
+//	Yellow cross
+//	Yellow edges
+//	Corner positioning
+//	Corner orientation
+//
+// Each segment has a description of the stage for the learner. The pieces are
+// found from the colors of the faces as the cube is held and the algorithms are
+// turned to face the pieces they work on, instead of turning the whole cube.
+// The solutions are much longer than those of Solve. The cube is checked with
+// RubiksCube.Validate first.
+func SolveBeginner(cube RubiksCube) (Solution, error) {
+	if err := cube.Validate(); err != nil {
+		return nil, err
+	}
+	s := &beginnerSolver{cube: cube}
+	stages := []struct {
+		stage, description string
+		solve              func()
+	}{
+		{"White cross", "Hold the cube with white on the bottom and move the four white edges down to make a white cross, with the other color of each edge matching the center beside it.", s.whiteCross},
+		{"First layer corners", "Turn U to bring each white corner above its slot, then repeat R U R' U' over the slot until the corner is solved with white on the bottom.", s.firstLayerCorners},
+		{"Second layer", "Turn U until an edge without yellow matches the center in front of it, then insert it to the right with U R U' R' U' F' U F or to the left with U' L' U L U F U' F'.", s.secondLayer},
+		{"Yellow cross", "Make a yellow cross on top with F R U R' U' F', holding a line of yellow from left to right or an L shape at the back and left.", s.yellowCross},
+		{"Yellow edges", "Turn U until two neighbouring yellow edges match their centers, then hold them at the back and right and swap the other two with R U R' U R U2 R' U.", s.yellowEdges},
+		{"Corner positioning", "Hold a corner which is in its place at the front right and cycle the other three with U R U' L' U R' U' L until every corner is in its place.", s.cornerPositioning},
+		{"Corner orientation", "Repeat R' D' R D on the front right corner until yellow is on top, turning only U to bring the next corner there, then turn U to finish the cube.", s.cornerOrientation},
+	}
+
+	solution := make(Solution, len(stages))
+	for i, st := range stages {
+		start := len(s.moves)
+		st.solve()
+		solution[i] = Segment{
+			Stage:       st.stage,
+			Description: st.description,
+			Algorithm:   Algorithm(s.moves[start:]).Simplify(),
+		}
+	}
+	return solution, nil
+}
+
+// beginnerSolver makes the moves of SolveBeginner on the cube.
+type beginnerSolver struct {
+	cube  RubiksCube
+	moves Algorithm
+}
+
+func (s *beginnerSolver) do(a Algorithm) {
+	s.cube = s.cube.Apply(a)
+	s.moves = append(s.moves, a...)
+}
+
+// doFrame makes the moves of the algorithm a as if the cube was held in frame
+// k.
+func (s *beginnerSolver) doFrame(a Algorithm, k int) {
+	o := StandardOrientation
+	for i := 0; i < k; i++ {
+		o = o.Rotate(RotationY)
+	}
+	z := make(Algorithm, len(a))
+	for i, m := range a {
+		z[i] = o.translate(m)
+	}
+	s.do(z)
+}
+
+// turnUp turns U clockwise n times, which moves the pieces of the U layer from
+// frame k to frame k-n.
+func (s *beginnerSolver) turnUp(n int) {
+	switch (n%4 + 4) % 4 {
+	case 1:
+		s.do(Algorithm{Up})
+	case 2:
+		s.do(Algorithm{Up2})
+	case 3:
+		s.do(Algorithm{UpPrime})
+	}
+}
+
+func (s *beginnerSolver) center(f Face) Color {
+	return s.cube.Face(f)[4]
+}
+
+// edgeColor returns the color of the edge in slot e on the face f, or
+// UnknownColor if the slot is not on that face.
+func (s *beginnerSolver) edgeColor(e EdgeSlot, f Face) Color {
+	for _, i := range edgeFaceletTable[e] {
+		if i.Face == f {
+			return s.cube.Face(f)[i.Index]
+		}
+	}
+	return UnknownColor
+}
+
+// cornerColor returns the color of the corner in slot c on the face f, or
+// UnknownColor if the slot is not on that face.
+func (s *beginnerSolver) cornerColor(c CornerSlot, f Face) Color {
+	for _, i := range cornerFaceletTable[c] {
+		if i.Face == f {
+			return s.cube.Face(f)[i.Index]
+		}
+	}
+	return UnknownColor
+}
+
+// findEdge returns the slot holding the edge with the colors a and b.
+func (s *beginnerSolver) findEdge(a, b Color) EdgeSlot {
+	faces := s.cube.faces()
+	for e, f := range edgeFaceletTable {
+		x, y := faces.at(f[0]), faces.at(f[1])
+		if x == a && y == b || x == b && y == a {
+			return EdgeSlot(e)
+		}
+	}
+	return 255
+}
+
+// findCorner returns the slot holding the corner with the colors a, b and c.
+func (s *beginnerSolver) findCorner(a, b, c Color) CornerSlot {
+	faces := s.cube.faces()
+	for i, f := range cornerFaceletTable {
+		if sameColors([]Color{faces.at(f[0]), faces.at(f[1]), faces.at(f[2])}, a, b, c) {
+			return CornerSlot(i)
+		}
+	}
+	return 255
+}
+
+// sameColors returns true if the colors are the colors want in any order.
+func sameColors(colors []Color, want ...Color) bool {
+	for _, w := range want {
+		found := false
+		for _, c := range colors {
+			found = found || c == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(colors) == len(want)
+}
+
+// slotFrame returns the frame of the slot in one of the frame slot tables, or
+// -1 if the slot is not in the table.
+func slotFrame[T comparable](slot T, table [4]T) int {
+	for k, i := range table {
+		if i == slot {
+			return k
+		}
+	}
+	return -1
+}
+
+// whiteCross turns the cube to hold white on the bottom, then moves each white
+// edge into the U layer and down above its center.
+func (s *beginnerSolver) whiteCross() {
+	s.do(s.cube.Orientation.rotationsToHold(FaceUp, FaceDown))
+
+	white := s.center(FaceDown)
+	for k, side := range beginnerSides {
+		color := s.center(side)
+		for {
+			e := s.findEdge(white, color)
+			if e == frameDownEdges[k] && s.edgeColor(e, FaceDown) == white {
+				break
+			}
+			if j := slotFrame(e, frameDownEdges); j >= 0 {
+				s.doFrame(Algorithm{Front2}, j)
+			} else if j := slotFrame(e, frameMiddleEdges); j >= 0 {
+				s.doFrame(Algorithm{FrontPrime, Up, Front}, j)
+			} else if j := slotFrame(e, frameUpEdges); j != k {
+				s.turnUp(j - k)
+			} else if s.edgeColor(e, FaceUp) == white {
+				s.doFrame(Algorithm{Front2}, k)
+			} else {
+				s.doFrame(beginnerCrossFlip, k)
+			}
+		}
+	}
+}
+
+// firstLayerCorners moves each white corner above its slot and repeats the
+// trigger until it is solved.
+func (s *beginnerSolver) firstLayerCorners() {
+	white := s.center(FaceDown)
+	for k := range beginnerSides {
+		a, b := s.center(beginnerSides[k]), s.center(beginnerSides[(k+1)%4])
+		for {
+			c := s.findCorner(white, a, b)
+			if c == frameDownCorners[k] && s.cornerColor(c, FaceDown) == white {
+				break
+			}
+			if j := slotFrame(c, frameDownCorners); j >= 0 {
+				s.doFrame(beginnerCornerTrigger, j)
+			} else if j := slotFrame(c, frameUpCorners); j != k {
+				s.turnUp(j - k)
+			} else {
+				s.doFrame(beginnerCornerTrigger, k)
+			}
+		}
+	}
+}
+
+// secondLayer takes each middle edge out of the wrong slot, then moves it in
+// front of its center and inserts it to the right or left.
+func (s *beginnerSolver) secondLayer() {
+	for k := range beginnerSides {
+		next := (k + 1) % 4
+		a, b := s.center(beginnerSides[k]), s.center(beginnerSides[next])
+		for {
+			e := s.findEdge(a, b)
+			if e == frameMiddleEdges[k] && s.edgeColor(e, beginnerSides[k]) == a {
+				break
+			}
+			if j := slotFrame(e, frameMiddleEdges); j >= 0 {
+				s.doFrame(beginnerRightInsert, j)
+				continue
+			}
+			j := slotFrame(e, frameUpEdges)
+			switch side := s.edgeColor(e, beginnerSides[j]); {
+			case side == a && j != k:
+				s.turnUp(j - k)
+			case side == a:
+				s.doFrame(beginnerRightInsert, k)
+			case j != next:
+				s.turnUp(j - next)
+			default:
+				s.doFrame(beginnerLeftInsert, next)
+			}
+		}
+	}
+}
+
+// yellowCross orients the yellow edges, going from a dot to an L shape to a
+// line to a cross.
+func (s *beginnerSolver) yellowCross() {
+	yellow := s.center(FaceUp)
+	for {
+		var up [4]bool
+		n := 0
+		for j, e := range frameUpEdges {
+			up[j] = s.edgeColor(e, FaceUp) == yellow
+			if up[j] {
+				n++
+			}
+		}
+		if n == 4 {
+			return
+		}
+		frame := 0
+		for k := range up {
+			line := up[(k+1)%4] && up[(k+3)%4]
+			backLeft := up[(k+2)%4] && up[(k+3)%4]
+			if n == 2 && (line || backLeft) {
+				frame = k
+				break
+			}
+		}
+		s.doFrame(beginnerYellowCross, frame)
+	}
+}
+
+// yellowEdges turns U until two neighbouring edges match their centers and
+// swaps the other two.
+func (s *beginnerSolver) yellowEdges() {
+	for {
+		frame := -1
+		turns := 0
+	search:
+		for n := 0; n < 4; n++ {
+			var match [4]bool
+			count := 0
+			for j, side := range beginnerSides {
+				// after n turns of U the edge now in frame j+n is in frame j
+				e := frameUpEdges[(j+n)%4]
+				match[j] = s.edgeColor(e, beginnerSides[(j+n)%4]) == s.center(side)
+				if match[j] {
+					count++
+				}
+			}
+			if count == 4 {
+				s.turnUp(n)
+				return
+			}
+			for k := range match {
+				if match[(k+1)%4] && match[(k+2)%4] {
+					frame, turns = k, n
+					break search
+				}
+			}
+		}
+		if frame < 0 {
+			// two opposite edges match, so swap two others to make
+			// neighbouring edges match
+			frame = 0
+		}
+		s.turnUp(turns)
+		s.doFrame(beginnerEdgeSwap, frame)
+	}
+}
+
+// cornerPositioning cycles the corners of the U layer around a corner which is
+// already in place until they are all in place.
+func (s *beginnerSolver) cornerPositioning() {
+	yellow := s.center(FaceUp)
+	for {
+		placed, frame := 0, 0
+		for k, c := range frameUpCorners {
+			colors := []Color{s.cornerColor(c, FaceUp), s.cornerColor(c, beginnerSides[k]), s.cornerColor(c, beginnerSides[(k+1)%4])}
+			if sameColors(colors, yellow, s.center(beginnerSides[k]), s.center(beginnerSides[(k+1)%4])) {
+				placed++
+				frame = k
+			}
+		}
+		if placed == 4 {
+			return
+		}
+		s.doFrame(beginnerCornerCycle, frame)
+	}
+}
+
+// cornerOrientation twists each corner of the U layer at the front right
+// until yellow is on top, which mixes up the lower layers until every corner
+// is done.
+func (s *beginnerSolver) cornerOrientation() {
+	yellow := s.center(FaceUp)
+	for {
+		j := -1
+		for k, c := range frameUpCorners {
+			if s.cornerColor(c, FaceUp) != yellow {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			break
+		}
+		s.turnUp(j)
+		for s.cornerColor(SlotUFR, FaceUp) != yellow {
+			s.do(beginnerCornerTwist)
+		}
+	}
+	for n := 0; n < 4 && !s.cube.IsSolved(); n++ {
+		s.turnUp(1)
+	}
+}
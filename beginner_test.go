@@ -0,0 +1,56 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveBeginner(t *testing.T) {
+	solution, err := SolveBeginner(NewSolvedCube())
+	assert.NoError(t, err)
+	assert.Equal(t, "z2 // White cross (0)\n// First layer corners (0)\n// Second layer (0)\n// Yellow cross (0)\n// Yellow edges (0)\n// Corner positioning (0)\n// Corner orientation (0)", solution.String())
+	for _, seg := range solution {
+		assert.NotEmpty(t, seg.Description)
+	}
+
+	// no rotation is needed when white is already on the bottom
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "x2 R U R' U'"))
+	solution, err = SolveBeginner(cube)
+	assert.NoError(t, err)
+	assert.Empty(t, solution[0].Algorithm)
+	assert.Equal(t, "R U R' U' R U R' U' R U R' U' R U R' U' R U R' U'", solution[1].Algorithm.String())
+
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 50; i++ {
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 40)).Hold(Orientation(r.Intn(24)))
+		solution, err := SolveBeginner(cube)
+		assert.NoError(t, err)
+		assert.Len(t, solution, 7)
+
+		// after the second layer only the yellow face is left
+		cube = cube.Apply(solution[0].Algorithm.Concat(solution[1].Algorithm, solution[2].Algorithm))
+		assert.Equal(t, FaceData{White, White, White, White, White, White, White, White, White}, cube.Face(FaceDown))
+		for _, f := range beginnerSides {
+			face := cube.Face(f)
+			for j := 3; j < 9; j++ {
+				assert.Equal(t, face[4], face[j])
+			}
+		}
+
+		cube = cube.Apply(solution[3].Algorithm)
+		for _, j := range []int{1, 3, 5, 7} {
+			assert.Equal(t, Yellow, cube.Face(FaceUp)[j])
+		}
+		cube = cube.Apply(solution[4].Algorithm.Concat(solution[5].Algorithm, solution[6].Algorithm))
+		assert.True(t, cube.IsSolved())
+	}
+
+	cube, _ = NewCubeBuilder(NewSolvedCube()).TwistCorner(SlotUFR, 1).AllowIllegal().Build()
+	_, err = SolveBeginner(cube)
+	assert.ErrorIs(t, err, ErrTwistedCorner)
+}
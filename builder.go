@@ -0,0 +1,208 @@
+package rubiks_cube
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidSlot    = errors.New("invalid slot")
+	ErrCenterFacelet  = errors.New("center facelets cannot be changed")
+	ErrInvalidFacelet = errors.New("invalid facelet")
+)
+
+// CubeBuilder makes a cube by changing the cubelets directly instead of
+// turning the faces, which allows states that cannot be reached by moves.
+//
+// Each method returns the builder so calls can be chained. The first error is
+// kept and returned by Build and every later change is ignored.
+type CubeBuilder struct {
+	cube         RubiksCube
+	faces        *CubeFaceData
+	allowIllegal bool
+	err          error
+}
+
+// NewCubeBuilder starts building from the cube start. The slots are named by
+// the faces of the standard orientation while facelets are on the faces as the
+// cube is held, see RubiksCube.Face.
+func NewCubeBuilder(start RubiksCube) *CubeBuilder {
+	return &CubeBuilder{cube: start}
+}
+
+// AllowIllegal stops Build from checking the cube can be solved.
+func (b *CubeBuilder) AllowIllegal() *CubeBuilder {
+	b.allowIllegal = true
+	return b
+}
+
+// Build returns the cube. Unless AllowIllegal was called the cube is checked
+// with RubiksCube.Validate.
+func (b *CubeBuilder) Build() (RubiksCube, error) {
+	b.flush()
+	if b.err != nil {
+		return RubiksCube{}, b.err
+	}
+	if !b.allowIllegal {
+		if err := b.cube.Validate(); err != nil {
+			return RubiksCube{}, err
+		}
+	}
+	return b.cube, nil
+}
+
+// ok returns true if the builder can make more changes. Facelets changed by
+// SetSticker are turned back into cubelets first.
+func (b *CubeBuilder) ok() bool {
+	b.flush()
+	return b.err == nil
+}
+
+func (b *CubeBuilder) fail(err error) *CubeBuilder {
+	b.err = err
+	return b
+}
+
+// flush finds the cubelets shown by the facelets changed by SetSticker.
+func (b *CubeBuilder) flush() {
+	if b.faces == nil || b.err != nil {
+		return
+	}
+	o := b.cube.Orientation
+	cube, bad := detectCubelets(standardFaces(o, *b.faces))
+	if bad != nil {
+		b.err = newPieceError(o, *b.faces, bad)
+		return
+	}
+	cube.Orientation = o
+	b.cube = cube
+	b.faces = nil
+}
+
+// SetCorner puts the corner cubelet c in the slot s.
+func (b *CubeBuilder) SetCorner(s CornerSlot, c CornerCubelet) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	if !s.Valid() {
+		return b.fail(ErrInvalidSlot)
+	}
+	b.cube.SetCorner(s, c)
+	return b
+}
+
+// SetEdge puts the edge cubelet e in the slot s.
+func (b *CubeBuilder) SetEdge(s EdgeSlot, e EdgeCubelet) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	if !s.Valid() {
+		return b.fail(ErrInvalidSlot)
+	}
+	b.cube.SetEdge(s, e)
+	return b
+}
+
+// SwapCorners swaps the corners in the slots x and y keeping their twist.
+func (b *CubeBuilder) SwapCorners(x, y CornerSlot) *CubeBuilder {
+	return b.CycleCorners(x, y)
+}
+
+// SwapEdges swaps the edges in the slots x and y keeping their flip.
+func (b *CubeBuilder) SwapEdges(x, y EdgeSlot) *CubeBuilder {
+	return b.CycleEdges(x, y)
+}
+
+// CycleCorners moves the corner in each slot to the next slot and the corner
+// in the last slot to the first, like the cycles of RubiksCube.Cycles. The
+// twist of each corner is kept.
+func (b *CubeBuilder) CycleCorners(slots ...CornerSlot) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	var seen [8]bool
+	corners := make([]CornerCubelet, len(slots))
+	for i, s := range slots {
+		if !s.Valid() || seen[s] {
+			return b.fail(ErrInvalidSlot)
+		}
+		seen[s] = true
+		corners[i], _ = b.cube.CornerAt(s)
+	}
+	for i, c := range corners {
+		from, to := slots[i], slots[(i+1)%len(slots)]
+		b.cube.SetCorner(to, MakeCornerCubelet(c.Piece(), twistFacingTable[to.Position()][c.Twist(from.Position())]))
+	}
+	return b
+}
+
+// CycleEdges moves the edge in each slot to the next slot and the edge in the
+// last slot to the first, like the cycles of RubiksCube.Cycles. The flip of
+// each edge is kept.
+func (b *CubeBuilder) CycleEdges(slots ...EdgeSlot) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	var seen [12]bool
+	edges := make([]EdgeCubelet, len(slots))
+	for i, s := range slots {
+		if !s.Valid() || seen[s] {
+			return b.fail(ErrInvalidSlot)
+		}
+		seen[s] = true
+		edges[i], _ = b.cube.EdgeAt(s)
+	}
+	for i, e := range edges {
+		b.cube.SetEdge(slots[(i+1)%len(slots)], e)
+	}
+	return b
+}
+
+// TwistCorner twists the corner in the slot s clockwise n times, a negative n
+// twists the corner anti-clockwise.
+func (b *CubeBuilder) TwistCorner(s CornerSlot, n int) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	if !s.Valid() {
+		return b.fail(ErrInvalidSlot)
+	}
+	c, _ := b.cube.CornerAt(s)
+	twist := (int(c.Twist(s.Position())) + n%3 + 3) % 3
+	b.cube.SetCorner(s, MakeCornerCubelet(c.Piece(), twistFacingTable[s.Position()][twist]))
+	return b
+}
+
+// FlipEdge flips the edge in the slot s.
+func (b *CubeBuilder) FlipEdge(s EdgeSlot) *CubeBuilder {
+	if !b.ok() {
+		return b
+	}
+	if !s.Valid() {
+		return b.fail(ErrInvalidSlot)
+	}
+	e, _ := b.cube.EdgeAt(s)
+	b.cube.SetEdge(s, MakeEdgeCubelet(e.Piece(), e.Rotation()^1))
+	return b
+}
+
+// SetSticker changes the color of the facelet f. Several stickers can be
+// changed in a row and the colors only need to form real corners and edges
+// once another change is made or the cube is built, otherwise a *PieceError
+// is returned by Build.
+func (b *CubeBuilder) SetSticker(f Facelet, c Color) *CubeBuilder {
+	if b.err != nil {
+		return b
+	}
+	switch {
+	case !f.Face.Valid() || f.Index < 0 || f.Index >= 9 || !c.Valid():
+		return b.fail(ErrInvalidFacelet)
+	case f.Index == 4:
+		return b.fail(ErrCenterFacelet)
+	}
+	if b.faces == nil {
+		faces := b.cube.faces()
+		b.faces = &faces
+	}
+	b.faces[f.Face][f.Index] = c
+	return b
+}
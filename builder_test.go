@@ -0,0 +1,70 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCubeBuilder(t *testing.T) {
+	cube, err := NewCubeBuilder(NewSolvedCube()).
+		TwistCorner(SlotUFR, 1).
+		TwistCorner(SlotUBL, -1).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "(UFR+)(UBL-)", cube.Cycles().String())
+
+	_, err = NewCubeBuilder(NewSolvedCube()).SwapEdges(SlotUF, SlotUR).Build()
+	assert.ErrorIs(t, err, ErrParity)
+	cube, err = NewCubeBuilder(NewSolvedCube()).SwapEdges(SlotUF, SlotUR).AllowIllegal().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "(UR UF)", cube.Cycles().String())
+
+	_, err = NewCubeBuilder(NewSolvedCube()).FlipEdge(SlotDB).Build()
+	assert.ErrorIs(t, err, ErrFlippedEdge)
+
+	_, err = NewCubeBuilder(NewSolvedCube()).CycleCorners(SlotUFR, SlotUFR).Build()
+	assert.ErrorIs(t, err, ErrInvalidSlot)
+}
+
+func TestCubeBuilder_Cycle(t *testing.T) {
+	cube, err := NewCubeBuilder(NewSolvedCube()).CycleEdges(SlotUF, SlotUR, SlotUL).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, NewSolvedCube().Apply(mustParseAlgorithm(t, "R U' R U R U R U' R' U' R2")), cube)
+
+	// the corners keep their twist when they move to a slot of the other
+	// handedness
+	cube, err = NewCubeBuilder(NewSolvedCube()).CycleCorners(SlotUFR, SlotUFL, SlotUBL, SlotUBR).CycleEdges(SlotUR, SlotUF, SlotUL, SlotUB).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, NewSolvedCube().Move(Up), cube)
+
+	start := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U F y"))
+	cube, err = NewCubeBuilder(start).SwapCorners(SlotDFR, SlotDBL).SwapCorners(SlotDFR, SlotDBL).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, start, cube)
+}
+
+func TestCubeBuilder_SetSticker(t *testing.T) {
+	// twist the top front right corner of a held cube by moving its stickers
+	start := NewSolvedCube().Hold(NewOrientation(FaceFront, FaceDown))
+	up, front, right := Facelet{FaceUp, 8}, Facelet{FaceFront, 2}, Facelet{FaceRight, 0}
+	b := NewCubeBuilder(start).
+		SetSticker(up, start.Face(FaceRight)[0]).
+		SetSticker(front, start.Face(FaceUp)[8]).
+		SetSticker(right, start.Face(FaceFront)[2])
+	_, err := b.Build()
+	assert.ErrorIs(t, err, ErrTwistedCorner)
+	cube, err := b.AllowIllegal().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, start.Orientation, cube.Orientation)
+	assert.Equal(t, start.Face(FaceRight)[0], cube.Face(FaceUp)[8])
+	assert.Len(t, cube.Cycles(), 1)
+
+	_, err = NewCubeBuilder(NewSolvedCube()).SetSticker(up, Yellow).FlipEdge(SlotUF).Build()
+	var pieceErr *PieceError
+	if assert.ErrorAs(t, err, &pieceErr) {
+		assert.Equal(t, []Facelet{up, front, right}, pieceErr.Facelets)
+	}
+
+	_, err = NewCubeBuilder(NewSolvedCube()).SetSticker(Facelet{FaceUp, 4}, Yellow).Build()
+	assert.ErrorIs(t, err, ErrCenterFacelet)
+}
@@ -0,0 +1,338 @@
+package rubiks_cube
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidColor = errors.New("invalid color")
+
+// CFOPOptions changes how SolveCFOP solves the cube.
+type CFOPOptions struct {
+	// CrossColor is the color of the cross, which is solved on the bottom.
+	// The zero value is White.
+	CrossColor Color
+}
+
+// The edges of the cross and the corner and edge of each F2L slot as a
+// CubieCube numbers them, for a cube held with the cross on the bottom. The
+// slots are front right, front left, back left and back right.
+var (
+	cfopCrossEdges  = [4]byte{4, 5, 6, 7}
+	cfopSlotCorners = [4]byte{4, 5, 6, 7}
+	cfopSlotEdges   = [4]byte{8, 9, 10, 11}
+	cfopSlotNames   = [4]string{"front right", "front left", "back left", "back right"}
+	cfopSlotFaces   = [4][2]Face{{FaceFront, FaceRight}, {FaceFront, FaceLeft}, {FaceBack, FaceLeft}, {FaceBack, FaceRight}}
+)
+
+// cfopF2LMoves are the moves used to solve F2L, which leave the cross alone
+// except while a pair is inserted.
+var cfopF2LMoves = []Move{Up, UpPrime, Up2, Right, RightPrime, Right2, Left, LeftPrime, Left2, Front, FrontPrime, Front2, Back, BackPrime, Back2}
+
+// SolveCFOP solves the cube with the CFOP method. The cube is turned to hold
+// the cross color on the bottom, then the solution has a segment for each
+// stage:
+//
+//	Cross: the fewest moves which solve the cross, at most 8
+//	F2L 1 to F2L 4: the fewest moves which solve a corner and edge pair,
+//	taking the slot with the shortest solution each time
+//	OLL: an algorithm from the built in set of 57 OLL cases
+//	PLL: an algorithm from the built in set of 21 PLL cases
+//
+// The last layer is recognised with RecognizeOLL and RecognizePLL and the
+// descriptions name the case used. The cube is checked with
+// RubiksCube.Validate first.
+func SolveCFOP(cube RubiksCube, opts CFOPOptions) (Solution, error) {
+	if !opts.CrossColor.Valid() {
+		return nil, ErrInvalidColor
+	}
+	if err := cube.Validate(); err != nil {
+		return nil, err
+	}
+	cfopOnce.Do(func() {
+		cfopTables = generateCFOPTables()
+	})
+	t := cfopTables
+
+	var crossFace Face
+	for f, c := range centerColorTable {
+		if c == opts.CrossColor {
+			crossFace = Face(f)
+		}
+	}
+	crossAlgorithm := cube.Orientation.rotationsToHold(crossFace, FaceDown)
+	cube = cube.Apply(crossAlgorithm)
+
+	// the held cube turns with the moves as they are written for the cube as
+	// it is held
+	rot := orientationCubies[cube.Orientation]
+	c := rot.Inverse().Multiply(cube.CubieCube()).Multiply(rot)
+
+	cross := t.solveCross(c)
+	crossAlgorithm = crossAlgorithm.Concat(cross)
+	for _, m := range cross {
+		c = c.Multiply(t.moves[m])
+	}
+	solution := Solution{{
+		Stage:       "Cross",
+		Description: fmt.Sprintf("Solve the %s cross on the bottom in the fewest moves", strings.ToLower(opts.CrossColor.String())),
+		Algorithm:   crossAlgorithm,
+	}}
+	cube = cube.Apply(cross)
+
+	var solved []int
+	for n := 1; n <= len(cfopSlotCorners); n++ {
+		slot, pair := t.solvePair(c, solved)
+		solved = append(solved, slot)
+		for _, m := range pair {
+			c = c.Multiply(t.moves[m])
+		}
+		a, b := cube.Face(cfopSlotFaces[slot][0])[4], cube.Face(cfopSlotFaces[slot][1])[4]
+		solution = append(solution, Segment{
+			Stage:       fmt.Sprintf("F2L %d", n),
+			Description: fmt.Sprintf("Insert the %s and %s pair into the %s slot", strings.ToLower(a.String()), strings.ToLower(b.String()), cfopSlotNames[slot]),
+			Algorithm:   pair,
+		})
+		cube = cube.Apply(pair)
+	}
+
+	oll, err := RecognizeOLL(cube)
+	if err != nil {
+		return nil, err
+	}
+	description := "The last layer is already oriented"
+	if oll.Name != "" {
+		description = "Orient the last layer with " + oll.Name
+	}
+	solution = append(solution, Segment{Stage: "OLL", Description: description, Algorithm: oll.Solution()})
+	cube = cube.Apply(oll.Solution())
+
+	pll, err := RecognizePLL(cube)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case pll.Name != "":
+		description = "Permute the last layer with " + pll.Name
+	case len(pll.PreAUF) > 0:
+		description = "The last layer only needs to be turned"
+	default:
+		description = "The last layer is already solved"
+	}
+	solution = append(solution, Segment{Stage: "PLL", Description: description, Algorithm: pll.Solution()})
+	return solution, nil
+}
+
+var (
+	cfopOnce   sync.Once
+	cfopTables *cfopSearchTables
+)
+
+// cfopSearchTables follow single pieces of a CubieCube through the face moves.
+// A corner is numbered by its position times 3 plus its twist and an edge by
+// its position times 2 plus its flip.
+type cfopSearchTables struct {
+	moves      [faceMoveCount]CubieCube
+	cornerMove [24][faceMoveCount]byte
+	edgeMove   [24][faceMoveCount]byte
+
+	// cross holds the distance of every position of the cross edges, see
+	// crossIndex, and pairs the distance of the corner and edge of each slot
+	// using the F2L moves.
+	cross PruneTable
+	pairs [4]PruneTable
+}
+
+func generateCFOPTables() *cfopSearchTables {
+	t := new(cfopSearchTables)
+	for m := range t.moves {
+		t.moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	for m, move := range t.moves {
+		// the piece from position move.CP[i] moves to position i
+		for i := range move.CP {
+			for twist := byte(0); twist < 3; twist++ {
+				t.cornerMove[int(move.CP[i])*3+int(twist)][m] = byte(i*3) + (twist+move.CO[i])%3
+			}
+		}
+		for i := range move.EP {
+			for flip := byte(0); flip < 2; flip++ {
+				t.edgeMove[int(move.EP[i])*2+int(flip)][m] = byte(i*2) + (flip+move.EO[i])%2
+			}
+		}
+	}
+
+	t.cross = make(PruneTable, 24*24*24*24)
+	for i := range t.cross {
+		t.cross[i] = unknownDistance
+	}
+	var start [4]byte
+	for i, e := range cfopCrossEdges {
+		start[i] = e * 2
+	}
+	t.cross[crossIndex(start)] = 0
+	queue := [][4]byte{start}
+	for len(queue) > 0 {
+		edges := queue[0]
+		queue = queue[1:]
+		d := t.cross[crossIndex(edges)]
+		for m := 0; m < faceMoveCount; m++ {
+			var next [4]byte
+			for i, e := range edges {
+				next[i] = t.edgeMove[e][m]
+			}
+			if j := crossIndex(next); t.cross[j] == unknownDistance {
+				t.cross[j] = d + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	// the pair tables are small enough to reuse generatePruneTable with the
+	// corners and edges as coordinates, which start at the slot
+	var corners, edges MoveTable = make(MoveTable, 24), make(MoveTable, 24)
+	for slot := range t.pairs {
+		for i := 0; i < 24; i++ {
+			for m := 0; m < faceMoveCount; m++ {
+				corners[i][m] = uint16((int(t.cornerMove[(i+int(cfopSlotCorners[slot])*3)%24][m]) - int(cfopSlotCorners[slot])*3 + 24) % 24)
+				edges[i][m] = uint16((int(t.edgeMove[(i+int(cfopSlotEdges[slot])*2)%24][m]) - int(cfopSlotEdges[slot])*2 + 24) % 24)
+			}
+		}
+		t.pairs[slot] = generatePruneTable(corners, edges, cfopF2LMoves)
+	}
+	return t
+}
+
+func crossIndex(edges [4]byte) int {
+	return ((int(edges[0])*24+int(edges[1]))*24+int(edges[2]))*24 + int(edges[3])
+}
+
+// pairIndex returns the entry of the pair table of the slot for the corner and
+// edge, which are numbered from the slot so the solved pair is 0.
+func pairIndex(slot int, corner, edge byte) int {
+	c := (int(corner) - int(cfopSlotCorners[slot])*3 + 24) % 24
+	e := (int(edge) - int(cfopSlotEdges[slot])*2 + 24) % 24
+	return c*24 + e
+}
+
+// solveCross finds the fewest moves which solve the cross edges of c by
+// following the cross table down to zero.
+func (t *cfopSearchTables) solveCross(c CubieCube) Algorithm {
+	var edges [4]byte
+	for i, e := range cfopCrossEdges {
+		edges[i] = findEdge(c, e)
+	}
+	z := Algorithm{}
+	for d := t.cross[crossIndex(edges)]; d > 0; d-- {
+		for m := 0; m < faceMoveCount; m++ {
+			var next [4]byte
+			for i, e := range edges {
+				next[i] = t.edgeMove[e][m]
+			}
+			if t.cross[crossIndex(next)] == d-1 {
+				z = append(z, Move(m))
+				edges = next
+				break
+			}
+		}
+	}
+	return z
+}
+
+// findEdge returns the position times 2 plus the flip of the edge e in c.
+func findEdge(c CubieCube, e byte) byte {
+	for i, j := range c.EP {
+		if j == e {
+			return byte(i)*2 + c.EO[i]
+		}
+	}
+	return 0
+}
+
+// findCorner returns the position times 3 plus the twist of the corner p in c.
+func findCorner(c CubieCube, p byte) byte {
+	for i, j := range c.CP {
+		if j == p {
+			return byte(i)*3 + c.CO[i]
+		}
+	}
+	return 0
+}
+
+// f2lState holds the cross edges and the pair of each slot during the search
+// for a pair.
+type f2lState struct {
+	cross   [4]byte
+	corners [4]byte
+	edges   [4]byte
+}
+
+// solvePair finds the slot which is not yet solved with the shortest solution
+// keeping the cross and the solved slots, and that solution.
+func (t *cfopSearchTables) solvePair(c CubieCube, solved []int) (int, Algorithm) {
+	var start f2lState
+	for i, e := range cfopCrossEdges {
+		start.cross[i] = findEdge(c, e)
+	}
+	for slot := range cfopSlotCorners {
+		start.corners[slot] = findCorner(c, cfopSlotCorners[slot])
+		start.edges[slot] = findEdge(c, cfopSlotEdges[slot])
+	}
+
+	bestSlot, best := -1, Algorithm(nil)
+	for slot := range cfopSlotCorners {
+		if slices.Contains(solved, slot) {
+			continue
+		}
+		keep := append([]int{slot}, solved...)
+		path := make(Algorithm, 0, 20)
+		for depth := t.f2lDistance(start, keep); best == nil || depth < len(best); depth++ {
+			if z, ok := t.searchPair(start, keep, path, depth); ok {
+				bestSlot, best = slot, z
+				break
+			}
+		}
+	}
+	return bestSlot, best
+}
+
+// f2lDistance is a lower bound for the moves needed to solve the cross and the
+// pairs of the slots in keep.
+func (t *cfopSearchTables) f2lDistance(s f2lState, keep []int) int {
+	d := int(t.cross[crossIndex(s.cross)])
+	for _, slot := range keep {
+		d = max(d, int(t.pairs[slot][pairIndex(slot, s.corners[slot], s.edges[slot])]))
+	}
+	return d
+}
+
+// searchPair looks for exactly togo more F2L moves after path which solve the
+// cross and the pairs of the slots in keep.
+func (t *cfopSearchTables) searchPair(s f2lState, keep []int, path Algorithm, togo int) (Algorithm, bool) {
+	if togo == 0 {
+		return append(Algorithm{}, path...), t.f2lDistance(s, keep) == 0
+	}
+	for _, m := range cfopF2LMoves {
+		if len(path) > 0 && !canFollow(path[len(path)-1], m) {
+			continue
+		}
+		var next f2lState
+		for i, e := range s.cross {
+			next.cross[i] = t.edgeMove[e][m]
+		}
+		for _, slot := range keep {
+			next.corners[slot] = t.cornerMove[s.corners[slot]][m]
+			next.edges[slot] = t.edgeMove[s.edges[slot]][m]
+		}
+		if t.f2lDistance(next, keep) >= togo {
+			continue
+		}
+		if z, ok := t.searchPair(next, keep, append(path, m), togo-1); ok {
+			return z, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,54 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveCFOP(t *testing.T) {
+	solution, err := SolveCFOP(NewSolvedCube(), CFOPOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "z2 // Cross (0)\n// F2L 1 (0)\n// F2L 2 (0)\n// F2L 3 (0)\n// F2L 4 (0)\n// OLL (0)\n// PLL (0)", solution.String())
+	for _, seg := range solution {
+		assert.NotEmpty(t, seg.Description)
+	}
+
+	// a sexy move from a solved cube is only one pair and a last layer
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "x2 R U R' U'"))
+	solution, err = SolveCFOP(cube, CFOPOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, solution[0].Algorithm)
+	assert.True(t, cube.Apply(solution.Algorithm()).IsSolved())
+
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 10; i++ {
+		color := Color(r.Intn(6))
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 40)).Hold(Orientation(r.Intn(24)))
+		solution, err := SolveCFOP(cube, CFOPOptions{CrossColor: color})
+		assert.NoError(t, err)
+		assert.Len(t, solution, 7)
+		assert.LessOrEqual(t, solution[0].Algorithm.HTM(), 8)
+
+		// the cross is on the bottom
+		cube = cube.Apply(solution[0].Algorithm)
+		assert.Equal(t, color, cube.Face(FaceDown)[4])
+		for _, j := range []int{1, 3, 5, 7} {
+			assert.Equal(t, color, cube.Face(FaceDown)[j])
+		}
+		for _, seg := range solution[1:5] {
+			cube = cube.Apply(seg.Algorithm)
+		}
+		assert.Equal(t, FaceData{color, color, color, color, color, color, color, color, color}, cube.Face(FaceDown))
+		cube = cube.Apply(solution[5].Algorithm)
+		assert.True(t, lastLayerOriented(cube))
+		assert.True(t, cube.Apply(solution[6].Algorithm).IsSolved())
+	}
+
+	_, err = SolveCFOP(NewSolvedCube(), CFOPOptions{CrossColor: Color(6)})
+	assert.ErrorIs(t, err, ErrInvalidColor)
+}
@@ -0,0 +1,467 @@
+// Command genalgs generates the last layer algorithms built into the package
+// and writes the cases of each set to a JSON file in a directory, which are
+// embedded by DefaultAlgorithmDatabase.
+//
+// The OLL and PLL cases and the main algorithms of the OLL, PLL and COLL cases
+// are written by hand in seeds.go. The COLL and ZBLL cases are found by going
+// through every last layer with the edges oriented, and the main algorithm of
+// each ZBLL case is the main algorithm of its COLL case followed by a PLL
+// which only moves the edges. Next the mirror and the inverse of every main
+// algorithm is added to the case it solves.
+//
+// Each ZBLL case and each PLL case from every angle is then solved with
+// SolveOptimal up to a fixed depth, falling back to the first solution from
+// Solve up to a fixed length. These are added as generated algorithms, and
+// each COLL case also takes the generated algorithms of the ZBLL and PLL
+// cases it covers.
+//
+// The searches do not depend on time, so the same files are written each time.
+// It takes several minutes with the default depth.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	rubiks_cube "github.com/MrMelon54/rubiks-cube"
+)
+
+type (
+	Algorithm     = rubiks_cube.Algorithm
+	AlgorithmCase = rubiks_cube.AlgorithmCase
+	AlgorithmSet  = rubiks_cube.AlgorithmSet
+	RubiksCube    = rubiks_cube.RubiksCube
+)
+
+// maxAlgorithms is the most algorithms kept for a case.
+const maxAlgorithms = 4
+
+var (
+	solved  = rubiks_cube.NewSolvedCube()
+	upTurns = []Algorithm{{}, {rubiks_cube.Up}, {rubiks_cube.Up2}, {rubiks_cube.UpPrime}}
+)
+
+// shapes names the shape of the corners of each OLL case with the edges
+// oriented, as short names for the IDs and longer names for the tags.
+var shapes = []struct{ oll, id, tag string }{
+	{"OLL 24", "T", "T"},
+	{"OLL 23", "U", "U"},
+	{"OLL 25", "L", "L"},
+	{"OLL 21", "H", "H"},
+	{"OLL 22", "Pi", "Pi"},
+	{"OLL 27", "S", "Sune"},
+	{"OLL 26", "AS", "Antisune"},
+}
+
+func main() {
+	out := flag.String("o", "algorithms", "output directory")
+	depth := flag.Int("depth", 14, "longest optimal search for each algorithm")
+	length := flag.Int("length", 16, "longest algorithm from the two phase solver when the optimal search fails")
+	flag.Parse()
+
+	g := &generator{depth: *depth, length: *length}
+	oll := g.seedCases(rubiks_cube.OLLSet, ollSeeds)
+	pll := g.seedCases(rubiks_cube.PLLSet, pllSeeds)
+	g.addReflections(oll)
+	g.addReflections(pll)
+	for i := range pll {
+		for _, auf := range upTurns {
+			g.add(&pll[i], g.search(solved.Apply(pll[i].Algorithms[0].Algorithm.Inverse().Concat(auf))), true)
+		}
+	}
+	coll, zbll := g.lastLayerCases(oll, pll)
+
+	for _, cases := range [][]AlgorithmCase{oll, pll, coll, zbll} {
+		set := cases[0].Set
+		f, err := os.Create(filepath.Join(*out, strings.ToLower(string(set))+".json"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := rubiks_cube.WriteAlgorithmCases(f, set, cases); err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("wrote %d %s cases", len(cases), set)
+	}
+}
+
+type generator struct {
+	depth, length int
+
+	// starts holds a cube matching the pattern of each case, solved by the
+	// first algorithm of the case.
+	starts map[string]RubiksCube
+}
+
+// newCase makes a case solved by the algorithm with no AUF. Turns of the U
+// layer at the start of the algorithm are made part of the case instead.
+func (g *generator) newCase(set AlgorithmSet, id, name string, tags []string, a Algorithm) AlgorithmCase {
+	if g.starts == nil {
+		g.starts = make(map[string]RubiksCube)
+	}
+	_, a = splitAUF(a)
+	start := solved.Apply(a.Inverse())
+	g.starts[id] = start
+	return AlgorithmCase{
+		Set:        set,
+		ID:         id,
+		Name:       name,
+		Pattern:    set.Pattern(start),
+		Tags:       tags,
+		Algorithms: []rubiks_cube.CaseAlgorithm{{AUF: Algorithm{}, Algorithm: a}},
+	}
+}
+
+func (g *generator) seedCases(set AlgorithmSet, seeds []seed) []AlgorithmCase {
+	z := make([]AlgorithmCase, len(seeds))
+	for i, s := range seeds {
+		a, err := rubiks_cube.ParseAlgorithm(s.algorithm)
+		if err != nil {
+			log.Fatalf("%s: %s", s.id, err)
+		}
+		z[i] = g.newCase(set, s.id, s.name, s.tags, a)
+	}
+	return z
+}
+
+// search finds the optimal algorithm which solves the cube if it is at most
+// g.depth moves, otherwise the first algorithm of at most g.length moves found
+// by Solve.
+func (g *generator) search(cube RubiksCube) Algorithm {
+	start := time.Now()
+	solution, err := rubiks_cube.SolveOptimal(context.Background(), cube, rubiks_cube.OptimalOptions{MaxDepth: g.depth})
+	if err != nil {
+		log.Fatal(err)
+	}
+	a := solution.Algorithm
+	if !solution.Optimal {
+		b, err := rubiks_cube.Solve(cube, rubiks_cube.SolveOptions{MaxLength: g.length})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if b.HTM() < a.HTM() {
+			a = b
+		}
+	}
+	log.Printf("found %s (%d, optimal %t) in %s", a, a.HTM(), solution.Optimal, time.Since(start).Round(time.Millisecond))
+	return a
+}
+
+// splitAUF splits the turns of the U layer at the start of the algorithm from
+// the rest of it.
+func splitAUF(a Algorithm) (auf, rest Algorithm) {
+	n := 0
+	for n < len(a) && isUpTurn(a[n]) {
+		n++
+	}
+	return append(Algorithm{}, a[:n]...).Simplify(), a[n:]
+}
+
+func isUpTurn(m rubiks_cube.Move) bool {
+	return m == rubiks_cube.Up || m == rubiks_cube.UpPrime || m == rubiks_cube.Up2
+}
+
+// goal returns true if the cube is solved as far as the set solves it.
+func goal(set AlgorithmSet, cube RubiksCube) bool {
+	return cube.IsF2LSolved() && set.Pattern(cube) == set.Pattern(solved)
+}
+
+// add adds the algorithm to the case if it solves the start of the case with
+// some turns of the U layer before and after it. Turns of the U layer at the
+// start of the algorithm are moved into the AUF, and for OLLSet the turns at
+// the end are dropped. The algorithm is left out if the case already has one
+// making the same moves, even if it holds the cube another way.
+func (g *generator) add(c *AlgorithmCase, a Algorithm, generated bool) bool {
+	start := g.starts[c.ID]
+	for _, auf := range upTurns {
+		for _, post := range upTurns {
+			if !goal(c.Set, start.Apply(auf.Concat(a, post))) {
+				continue
+			}
+			auf, a := splitAUF(auf.Concat(a, post).Simplify())
+			if c.Set == rubiks_cube.OLLSet {
+				for len(a) > 0 && isUpTurn(a[len(a)-1]) {
+					a = a[:len(a)-1]
+				}
+			}
+			moves := auf.Concat(a).SimplifyAcrossRotations().String()
+			for _, b := range c.Algorithms {
+				if b.AUF.Concat(b.Algorithm).SimplifyAcrossRotations().String() == moves {
+					return true
+				}
+			}
+			c.Algorithms = append(c.Algorithms, rubiks_cube.CaseAlgorithm{AUF: auf, Algorithm: a, Generated: generated})
+			slices.SortStableFunc(c.Algorithms[1:], func(x, y rubiks_cube.CaseAlgorithm) int {
+				return x.Algorithm.HTM() - y.Algorithm.HTM()
+			})
+			c.Algorithms = c.Algorithms[:min(len(c.Algorithms), maxAlgorithms)]
+			return true
+		}
+	}
+	return false
+}
+
+// addReflections adds the mirror and the inverse of the main algorithm of
+// every case to the case of the set it solves.
+func (g *generator) addReflections(cases []AlgorithmCase) {
+	db, err := rubiks_cube.NewAlgorithmDatabase(cases)
+	if err != nil {
+		log.Fatal(err)
+	}
+	index := make(map[string]int)
+	for i, c := range cases {
+		index[c.ID] = i
+	}
+	var algorithms []Algorithm
+	for _, c := range cases {
+		algorithms = append(algorithms, c.Algorithms[0].AUF.Concat(c.Algorithms[0].Algorithm))
+	}
+	for _, a := range algorithms {
+		for _, b := range []Algorithm{a.Mirror(), a.Inverse(), a.Mirror().Inverse()} {
+			m, err := db.FindCase(cases[0].Set, solved.Apply(b.Inverse()))
+			if err == nil {
+				g.add(&cases[index[m.Case.ID]], b, false)
+			}
+		}
+	}
+}
+
+// lastLayerState is a last layer with the edges oriented along with the
+// smallest pattern of the cube after turning the U layer before or after it,
+// which is the same for every cube of a case.
+type lastLayerState struct {
+	cube      RubiksCube
+	zbll      string
+	coll      string
+	algorithm Algorithm
+}
+
+// lastLayerCases finds the COLL and ZBLL cases.
+func (g *generator) lastLayerCases(oll, pll []AlgorithmCase) (coll, zbll []AlgorithmCase) {
+	ollDB, err := rubiks_cube.NewAlgorithmDatabase(oll)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pllDB, err := rubiks_cube.NewAlgorithmDatabase(pll)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// every case is kept as the state with the smallest pattern
+	cases := make(map[string]*lastLayerState)
+	for _, cube := range lastLayerStates() {
+		s := &lastLayerState{
+			cube: cube,
+			zbll: smallestPattern(rubiks_cube.ZBLLSet, cube),
+			coll: smallestPattern(rubiks_cube.COLLSet, cube),
+		}
+		if t, found := cases[s.zbll]; !found || cube.LastLayerPattern() < t.cube.LastLayerPattern() {
+			cases[s.zbll] = s
+		}
+	}
+	groups := make(map[string][]*lastLayerState)
+	var collKeys []string
+	for _, s := range cases {
+		if _, found := groups[s.coll]; !found {
+			collKeys = append(collKeys, s.coll)
+		}
+		groups[s.coll] = append(groups[s.coll], s)
+	}
+	slices.Sort(collKeys)
+
+	shapeOf := func(s *lastLayerState) int {
+		m, err := ollDB.FindCase(rubiks_cube.OLLSet, s.cube)
+		if err != nil {
+			return len(shapes)
+		}
+		return slices.IndexFunc(shapes, func(x struct{ oll, id, tag string }) bool { return x.oll == m.Case.ID })
+	}
+	seeds := make(map[string]Algorithm)
+	for _, s := range collSeeds {
+		a, err := rubiks_cube.ParseAlgorithm(s.algorithm)
+		if err != nil {
+			log.Fatalf("%s: %s", s.id, err)
+		}
+		seeds[s.id] = a.Simplify()
+	}
+
+	// the hand written algorithms come first, so the search only adds more
+	var collGroups [][]*lastLayerState
+	var zbllStates []*lastLayerState
+	for shape := 0; shape <= len(shapes); shape++ {
+		n, zbllNumber := 0, 0
+		for _, key := range collKeys {
+			group := groups[key]
+			if shapeOf(group[0]) != shape {
+				continue
+			}
+			slices.SortFunc(group, func(x, y *lastLayerState) int { return strings.Compare(x.zbll, y.zbll) })
+
+			// the corners are already solved
+			if key == smallestPattern(rubiks_cube.COLLSet, solved) {
+				continue
+			}
+			n++
+			id, tag := "O", "corners oriented"
+			if shape < len(shapes) {
+				id, tag = shapes[shape].id, shapes[shape].tag
+			}
+			collID := fmt.Sprintf("COLL %s%d", id, n)
+			seed, found := seeds[collID]
+			if !found {
+				log.Fatalf("%s: no algorithm in collSeeds", collID)
+			}
+			c := g.newCase(rubiks_cube.COLLSet, collID, "", []string{tag}, seed)
+			if smallestPattern(rubiks_cube.COLLSet, g.starts[collID]) != key {
+				log.Fatalf("%s: the algorithm in collSeeds solves another case", collID)
+			}
+			coll = append(coll, c)
+			collGroups = append(collGroups, group)
+
+			if shape == len(shapes) {
+				continue
+			}
+			for _, s := range group {
+				zbllNumber++
+				a := g.composeZBLL(pllDB, s.cube, c.Algorithms[0].Algorithm)
+				zbll = append(zbll, g.newCase(rubiks_cube.ZBLLSet, fmt.Sprintf("ZBLL %s%d", id, zbllNumber), "", []string{tag, collID}, a))
+				zbllStates = append(zbllStates, s)
+			}
+		}
+	}
+	g.addReflections(coll)
+	g.addReflections(zbll)
+
+	for i := range zbll {
+		s := zbllStates[i]
+		s.algorithm = g.search(s.cube)
+		g.add(&zbll[i], s.algorithm, true)
+	}
+	for i, group := range collGroups {
+		var candidates []rubiks_cube.CaseAlgorithm
+		for _, s := range group {
+			if s.algorithm != nil {
+				candidates = append(candidates, rubiks_cube.CaseAlgorithm{Algorithm: s.algorithm, Generated: true})
+				continue
+			}
+			m, err := pllDB.FindCase(rubiks_cube.PLLSet, s.cube)
+			if err != nil {
+				continue
+			}
+			for _, a := range m.Case.Algorithms {
+				candidates = append(candidates, rubiks_cube.CaseAlgorithm{Algorithm: m.AUF.Concat(a.AUF, a.Algorithm, m.PostAUF), Generated: a.Generated})
+			}
+		}
+		slices.SortStableFunc(candidates, func(x, y rubiks_cube.CaseAlgorithm) int { return x.Algorithm.HTM() - y.Algorithm.HTM() })
+		for _, a := range candidates {
+			g.add(&coll[i], a.Algorithm, a.Generated)
+		}
+	}
+	return coll, zbll
+}
+
+// composeZBLL returns the algorithm of the COLL case of the cube followed by
+// the PLL which only moves the edges, with the turns of the U layer needed to
+// line them up.
+func (g *generator) composeZBLL(pllDB *rubiks_cube.AlgorithmDatabase, cube RubiksCube, coll Algorithm) Algorithm {
+	for _, auf := range upTurns {
+		for _, post := range upTurns {
+			a := auf.Concat(coll, post)
+			next := cube.Apply(a)
+			if !goal(rubiks_cube.COLLSet, next) {
+				continue
+			}
+			if goal(rubiks_cube.ZBLLSet, next) {
+				return a.Simplify()
+			}
+			m, err := pllDB.FindCase(rubiks_cube.PLLSet, next)
+			if err != nil || !slices.Contains(m.Case.Tags, "edges only") {
+				continue
+			}
+			pll := m.Case.Algorithms[0]
+			return a.Concat(m.AUF, pll.AUF, pll.Algorithm, m.PostAUF).Simplify()
+		}
+	}
+	log.Fatalf("no PLL of the edges finishes %s", coll)
+	return nil
+}
+
+// smallestPattern returns the smallest pattern of the set for the cube after
+// turning the U layer before or after it.
+func smallestPattern(set AlgorithmSet, cube RubiksCube) string {
+	var z string
+	for _, auf := range upTurns {
+		p := set.Pattern(cube.Apply(auf))
+		for n := 0; n < 4; n++ {
+			if q := shiftSides(p, n); z == "" || q < z {
+				z = q
+			}
+		}
+	}
+	return z
+}
+
+// shiftSides changes the letter of each side facelet of the pattern to the
+// next side n times.
+func shiftSides(p string, n int) string {
+	const sides = "FRBL"
+	b := []byte(p)
+	for i, c := range b {
+		if j := strings.IndexByte(sides, c); j >= 0 {
+			b[i] = sides[(j+n)%4]
+		}
+	}
+	return string(b)
+}
+
+// lastLayerStates returns every solvable cube with only the last layer
+// unsolved and the edges oriented.
+func lastLayerStates() []RubiksCube {
+	corners := []rubiks_cube.CornerSlot{rubiks_cube.SlotUFR, rubiks_cube.SlotUBR, rubiks_cube.SlotUBL, rubiks_cube.SlotUFL}
+	edges := []rubiks_cube.EdgeSlot{rubiks_cube.SlotUF, rubiks_cube.SlotUR, rubiks_cube.SlotUB, rubiks_cube.SlotUL}
+	var changes []func(*rubiks_cube.CubeBuilder)
+	for i := range corners {
+		i := i
+		changes = append(changes, func(b *rubiks_cube.CubeBuilder) { b.TwistCorner(corners[i], 1) })
+		for j := i + 1; j < len(corners); j++ {
+			j := j
+			changes = append(changes,
+				func(b *rubiks_cube.CubeBuilder) { b.SwapCorners(corners[i], corners[j]) },
+				func(b *rubiks_cube.CubeBuilder) { b.SwapEdges(edges[i], edges[j]) },
+			)
+		}
+	}
+
+	seen := map[string]bool{solved.LastLayerPattern(): true}
+	queue := []RubiksCube{solved}
+	for i := 0; i < len(queue); i++ {
+		for _, change := range changes {
+			b := rubiks_cube.NewCubeBuilder(queue[i]).AllowIllegal()
+			change(b)
+			cube, err := b.Build()
+			if err != nil {
+				log.Fatal(err)
+			}
+			if p := cube.LastLayerPattern(); !seen[p] {
+				seen[p] = true
+				queue = append(queue, cube)
+			}
+		}
+	}
+	var z []RubiksCube
+	for _, cube := range queue {
+		if cube.Validate() == nil {
+			z = append(z, cube)
+		}
+	}
+	return z
+}
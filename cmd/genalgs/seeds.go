@@ -0,0 +1,144 @@
+package main
+
+// seed is a case given by hand with the algorithm which solves it.
+type seed struct {
+	id, name  string
+	tags      []string
+	algorithm string
+}
+
+// ollSeeds are the 57 OLL cases, numbered as in the usual list and tagged
+// with the shape of the oriented facelets.
+var ollSeeds = []seed{
+	{"OLL 1", "", []string{"dot"}, "R U2 R2 F R F' U2 R' F R F'"},
+	{"OLL 2", "", []string{"dot"}, "F R U R' U' F' f R U R' U' f'"},
+	{"OLL 3", "", []string{"dot"}, "f R U R' U' f' U' F R U R' U' F'"},
+	{"OLL 4", "", []string{"dot"}, "f R U R' U' f' U F R U R' U' F'"},
+	{"OLL 5", "", []string{"square"}, "r' U2 R U R' U r"},
+	{"OLL 6", "", []string{"square"}, "r U2 R' U' R U' r'"},
+	{"OLL 7", "", []string{"small lightning"}, "r U R' U R U2 r'"},
+	{"OLL 8", "", []string{"small lightning"}, "r' U' R U' R' U2 r"},
+	{"OLL 9", "", []string{"fish"}, "R U R' U' R' F R2 U R' U' F'"},
+	{"OLL 10", "", []string{"fish"}, "R U R' U R' F R F' R U2 R'"},
+	{"OLL 11", "", []string{"small lightning"}, "r U R' U R' F R F' R U2 r'"},
+	{"OLL 12", "", []string{"small lightning"}, "M' R' U' R U' R' U2 R U' R r'"},
+	{"OLL 13", "", []string{"knight move"}, "F U R U' R2 F' R U R U' R'"},
+	{"OLL 14", "", []string{"knight move"}, "R' F R U R' F' R F U' F'"},
+	{"OLL 15", "", []string{"knight move"}, "r' U' r R' U' R U r' U r"},
+	{"OLL 16", "", []string{"knight move"}, "r U r' R U R' U' r U' r'"},
+	{"OLL 17", "", []string{"dot"}, "F R' F' R2 r' U R U' R' U' M'"},
+	{"OLL 18", "", []string{"dot"}, "r U R' U R U2 r2 U' R U' R' U2 r"},
+	{"OLL 19", "", []string{"dot"}, "r' R U R U R' U' M' R' F R F'"},
+	{"OLL 20", "", []string{"dot"}, "r U R' U' M2 U R U' R' U' M'"},
+	{"OLL 21", "H", []string{"cross"}, "R U2 R' U' R U R' U' R U' R'"},
+	{"OLL 22", "Pi", []string{"cross"}, "R U2 R2 U' R2 U' R2 U2 R"},
+	{"OLL 23", "Headlights", []string{"cross"}, "R2 D' R U2 R' D R U2 R"},
+	{"OLL 24", "Chameleon", []string{"cross"}, "r U R' U' r' F R F'"},
+	{"OLL 25", "Bowtie", []string{"cross"}, "F' r U R' U' r' F R"},
+	{"OLL 26", "Antisune", []string{"cross"}, "R U2 R' U' R U' R'"},
+	{"OLL 27", "Sune", []string{"cross"}, "R U R' U R U2 R'"},
+	{"OLL 28", "", []string{"corners oriented"}, "r U R' U' M U R U' R'"},
+	{"OLL 29", "", []string{"awkward"}, "R U R' U' R U' R' F' U' F R U R'"},
+	{"OLL 30", "", []string{"awkward"}, "F R' F R2 U' R' U' R U R' F2"},
+	{"OLL 31", "", []string{"P shape"}, "R' U' F U R U' R' F' R"},
+	{"OLL 32", "", []string{"P shape"}, "L U F' U' L' U L F L'"},
+	{"OLL 33", "", []string{"T shape"}, "R U R' U' R' F R F'"},
+	{"OLL 34", "", []string{"C shape"}, "R U R2 U' R' F R U R U' F'"},
+	{"OLL 35", "", []string{"fish"}, "R U2 R2 F R F' R U2 R'"},
+	{"OLL 36", "", []string{"W shape"}, "L' U' L U' L' U L U L F' L' F"},
+	{"OLL 37", "", []string{"fish"}, "F R' F' R U R U' R'"},
+	{"OLL 38", "", []string{"W shape"}, "R U R' U R U' R' U' R' F R F'"},
+	{"OLL 39", "", []string{"big lightning"}, "L F' L' U' L U F U' L'"},
+	{"OLL 40", "", []string{"big lightning"}, "R' F R U R' U' F' U R"},
+	{"OLL 41", "", []string{"awkward"}, "R U R' U R U2 R' F R U R' U' F'"},
+	{"OLL 42", "", []string{"awkward"}, "R' U' R U' R' U2 R F R U R' U' F'"},
+	{"OLL 43", "", []string{"P shape"}, "F' U' L' U L F"},
+	{"OLL 44", "", []string{"P shape"}, "F U R U' R' F'"},
+	{"OLL 45", "", []string{"T shape"}, "F R U R' U' F'"},
+	{"OLL 46", "", []string{"C shape"}, "R' U' R' F R F' U R"},
+	{"OLL 47", "", []string{"small L"}, "R' U' R' F R F' R' F R F' U R"},
+	{"OLL 48", "", []string{"small L"}, "F R U R' U' R U R' U' F'"},
+	{"OLL 49", "", []string{"small L"}, "r U' r2 U r2 U r2 U' r"},
+	{"OLL 50", "", []string{"small L"}, "r' U r2 U' r2 U' r2 U r'"},
+	{"OLL 51", "", []string{"line"}, "F U R U' R' U R U' R' F'"},
+	{"OLL 52", "", []string{"line"}, "R U R' U R U' B U' B' R'"},
+	{"OLL 53", "", []string{"small L"}, "r' U' R U' R' U R U' R' U2 r"},
+	{"OLL 54", "", []string{"small L"}, "r U R' U R U' R' U R U2 r'"},
+	{"OLL 55", "", []string{"line"}, "R' F R U R U' R2 F' R2 U' R' U R U R'"},
+	{"OLL 56", "", []string{"line"}, "r U r' U R U' R' U R U' R' r U' r'"},
+	{"OLL 57", "", []string{"corners oriented"}, "R U R' U' M' U R U' r'"},
+}
+
+// pllSeeds are the 21 PLL cases, tagged with how they move the corners.
+var pllSeeds = []seed{
+	{"Aa-perm", "", []string{"corners only"}, "x R' U R' D2 R U' R' D2 R2 x'"},
+	{"Ab-perm", "", []string{"corners only"}, "x R2 D2 R U R' D2 R U' R x'"},
+	{"E-perm", "", []string{"corners only"}, "x' R U' R' D R U R' D' R U R' D R U' R' D' x"},
+	{"F-perm", "", []string{"adjacent swap"}, "R' U' F' R U R' U' R' F R2 U' R' U' R U R' U R"},
+	{"Ga-perm", "", []string{"adjacent swap"}, "R2 U R' U R' U' R U' R2 U' D R' U R D'"},
+	{"Gb-perm", "", []string{"adjacent swap"}, "R' U' R U D' R2 U R' U R U' R U' R2 D"},
+	{"Gc-perm", "", []string{"adjacent swap"}, "R2 U' R U' R U R' U R2 U D' R U' R' D"},
+	{"Gd-perm", "", []string{"adjacent swap"}, "R U R' U' D R2 U' R U' R' U R' U R2 D'"},
+	{"H-perm", "", []string{"edges only"}, "M2 U M2 U2 M2 U M2"},
+	{"Ja-perm", "", []string{"adjacent swap"}, "R' U L' U2 R U' R' U2 R L U'"},
+	{"Jb-perm", "", []string{"adjacent swap"}, "R U R' F' R U R' U' R' F R2 U' R' U'"},
+	{"Na-perm", "", []string{"diagonal swap"}, "R U R' U R U R' F' R U R' U' R' F R2 U' R' U2 R U' R'"},
+	{"Nb-perm", "", []string{"diagonal swap"}, "R' U R U' R' F' U' F R U R' F R' F' R U' R"},
+	{"Ra-perm", "", []string{"adjacent swap"}, "R U' R' U' R U R D R' U' R D' R' U2 R' U'"},
+	{"Rb-perm", "", []string{"adjacent swap"}, "R2 F R U R U' R' F' R U2 R' U2 R"},
+	{"T-perm", "", []string{"adjacent swap"}, "R U R' U' R' F R2 U' R' U' R U R' F'"},
+	{"Ua-perm", "", []string{"edges only"}, "M2 U M U2 M' U M2"},
+	{"Ub-perm", "", []string{"edges only"}, "M2 U' M U2 M' U' M2"},
+	{"V-perm", "", []string{"diagonal swap"}, "R' U R' U' R D' R' D R' U D' R2 U' R2 D R2"},
+	{"Y-perm", "", []string{"diagonal swap"}, "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	{"Z-perm", "", []string{"edges only"}, "M' U M2 U M2 U M' U2 M2"},
+}
+
+// collSeeds are the main algorithms of the COLL cases, numbered in the order
+// the cases are found. Each one is an OCLL algorithm, which is one of OLL 21
+// to 27 or its mirror, or a PLL. Where one is not enough it is an OCLL
+// algorithm followed by another OCLL algorithm or a PLL to fix the corners.
+var collSeeds = []seed{
+	{"COLL T1", "", nil, "(R U R' U R U2 R') (L' U' L U' L' U2 L)"},
+	{"COLL T2", "", nil, "(R U2 R' U' R U' R') (l' U' L U l F' L' F)"},
+	{"COLL T3", "", nil, "(r U R' U' r' F R F') (x R2 D2 R U R' D2 R U' R x')"},
+	{"COLL T4", "", nil, "(r U R' U' r' F R F') U (R U2 R' U' R U' R')"},
+	{"COLL T5", "", nil, "r U R' U' r' F R F'"},
+	{"COLL T6", "", nil, "l' U' L U l F' L' F"},
+	{"COLL U1", "", nil, "(R U2 R' U' R U' R') (L2 D L' U2 L D' L' U2 L')"},
+	{"COLL U2", "", nil, "(R2 D' R U2 R' D R U2 R) (R' U L' U2 R U' R' U2 R L U')"},
+	{"COLL U3", "", nil, "(F' r U R' U' r' F R) (R U R' U R U2 R')"},
+	{"COLL U4", "", nil, "(R U2 R' U' R U' R') (L' U2 L U L' U L)"},
+	{"COLL U5", "", nil, "L2 D L' U2 L D' L' U2 L'"},
+	{"COLL U6", "", nil, "R2 D' R U2 R' D R U2 R"},
+	{"COLL L1", "", nil, "(R2 D' R U2 R' D R U2 R) (R U2 R' U' R U' R')"},
+	{"COLL L2", "", nil, "(r U R' U' r' F R F') (L' U' L U' L' U2 L)"},
+	{"COLL L3", "", nil, "F' r U R' U' r' F R"},
+	{"COLL L4", "", nil, "F l' U' L U l F' L'"},
+	{"COLL L5", "", nil, "(R U2 R' U' R U R' U' R U' R') (R U2 R' U' R U' R')"},
+	{"COLL L6", "", nil, "(F' r U R' U' r' F R) (R' U L' U2 R U' R' U2 R L U')"},
+	{"COLL H1", "", nil, "R U2 R' U' R U R' U' R U' R'"},
+	{"COLL H2", "", nil, "(r U R' U' r' F R F') (F' r U R' U' r' F R)"},
+	{"COLL H3", "", nil, "(F' r U R' U' r' F R) U (R U R' U R U2 R')"},
+	{"COLL H4", "", nil, "(F' r U R' U' r' F R) (R U2 R' U' R U' R')"},
+	{"COLL Pi1", "", nil, "(R U R' U R U2 R') (R2 D' R U2 R' D R U2 R)"},
+	{"COLL Pi2", "", nil, "R U2 R2 U' R2 U' R2 U2 R"},
+	{"COLL Pi3", "", nil, "(F' r U R' U' r' F R) U2 (l' U' L U l F' L' F)"},
+	{"COLL Pi4", "", nil, "(R U R' U R U2 R') (l' U' L U l F' L' F)"},
+	{"COLL Pi5", "", nil, "(R U2 R2 U' R2 U' R2 U2 R) (x R' U R' D2 R U' R' D2 R2 x')"},
+	{"COLL Pi6", "", nil, "(R U2 R2 U' R2 U' R2 U2 R) (R' U L' U2 R U' R' U2 R L U')"},
+	{"COLL S1", "", nil, "(r U R' U' r' F R F') (R U R' U R U2 R')"},
+	{"COLL S2", "", nil, "(R U R' U R U2 R') (x R' U R' D2 R U' R' D2 R2 x')"},
+	{"COLL S3", "", nil, "(r U R' U' r' F R F') (l' U' L U l F' L' F)"},
+	{"COLL S4", "", nil, "(R U R' U R U2 R') (F l' U' L U l F' L')"},
+	{"COLL S5", "", nil, "(r U R' U' r' F R F') (R2 D' R U2 R' D R U2 R)"},
+	{"COLL S6", "", nil, "R U R' U R U2 R'"},
+	{"COLL AS1", "", nil, "(R U2 R' U' R U' R') (x R' U R' D2 R U' R' D2 R2 x')"},
+	{"COLL AS2", "", nil, "(F' r U R' U' r' F R) (L' U' L U' L' U2 L)"},
+	{"COLL AS3", "", nil, "(R U2 R' U' R U' R') (F l' U' L U l F' L')"},
+	{"COLL AS4", "", nil, "(R U2 R' U' R U' R') (R U R' U' R' F R2 U' R' U' R U R' F')"},
+	{"COLL AS5", "", nil, "(r U R' U' r' F R F') U' (l' U' L U l F' L' F)"},
+	{"COLL AS6", "", nil, "R U2 R' U' R U' R'"},
+	{"COLL O1", "", nil, "x R' U R' D2 R U' R' D2 R2 x'"},
+	{"COLL O2", "", nil, "x' R U' R' D R U R' D' R U R' D R U' R' D' x"},
+}
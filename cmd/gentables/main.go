@@ -0,0 +1,45 @@
+// Command gentables generates the move and pruning tables used by the solvers
+// and writes them to a file which can be loaded with LoadTables or by setting
+// the RUBIKS_CUBE_TABLES environment variable. With -patterns it also writes
+// the pattern databases used by the optimal solver, which can be loaded with
+// LoadPatternDatabases or by setting RUBIKS_CUBE_PATTERNS.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	rubiks_cube "github.com/MrMelon54/rubiks-cube"
+)
+
+func main() {
+	out := flag.String("o", "tables.bin", "output file")
+	patterns := flag.String("patterns", "", "pattern databases output file")
+	flag.Parse()
+
+	tables := rubiks_cube.GenerateTables()
+	write(*out, tables)
+	if *patterns != "" {
+		write(*patterns, rubiks_cube.GeneratePatternDatabases(tables))
+	}
+}
+
+func write(path string, t io.WriterTo) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := bufio.NewWriter(f)
+	if _, err := t.WriteTo(w); err != nil {
+		log.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
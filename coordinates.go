@@ -0,0 +1,228 @@
+package rubiks_cube
+
+// The number of values of each coordinate of a CubieCube.
+const (
+	TwistCount             = 2187
+	FlipCount              = 2048
+	UDSliceCount           = 495
+	SliceSortedCount       = 11880
+	CornerPermutationCount = 40320
+	UDEdgePermutationCount = 40320
+	EdgePermutationCount   = 479001600
+)
+
+// sliceEdge is the first of the FR, FL, BL and BR edges, which are the edges
+// between the U and D faces, and sliceEdgePermutationSize is the number of
+// orders of these edges.
+const (
+	sliceEdge                = 8
+	sliceEdgePermutationSize = 24
+)
+
+// Twist returns the twist of the first seven corners as a number in base 3,
+// from 0 to 2186. The twist of the last corner follows from the others.
+func (c CubieCube) Twist() int {
+	n := 0
+	for _, i := range c.CO[:7] {
+		n = n*3 + int(i)
+	}
+	return n
+}
+
+// SetTwist sets the twist of the corners from the coordinate n, see Twist.
+func (c *CubieCube) SetTwist(n int) {
+	total := 0
+	for i := 6; i >= 0; i-- {
+		c.CO[i] = byte(n % 3)
+		total += n % 3
+		n /= 3
+	}
+	c.CO[7] = byte((3 - total%3) % 3)
+}
+
+// Flip returns the flip of the first eleven edges as a number in base 2, from
+// 0 to 2047. The flip of the last edge follows from the others.
+func (c CubieCube) Flip() int {
+	n := 0
+	for _, i := range c.EO[:11] {
+		n = n*2 + int(i)
+	}
+	return n
+}
+
+// SetFlip sets the flip of the edges from the coordinate n, see Flip.
+func (c *CubieCube) SetFlip(n int) {
+	total := 0
+	for i := 10; i >= 0; i-- {
+		c.EO[i] = byte(n % 2)
+		total += n % 2
+		n /= 2
+	}
+	c.EO[11] = byte(total % 2)
+}
+
+// UDSlice returns the positions of the FR, FL, BL and BR edges, ignoring their
+// order, from 0 to 494. This is 0 when the edges are between the U and D faces.
+func (c CubieCube) UDSlice() int {
+	return c.SliceSorted() / sliceEdgePermutationSize
+}
+
+// SetUDSlice puts the FR, FL, BL and BR edges in the positions from the
+// coordinate n, see UDSlice. The other edges fill the remaining positions in
+// order.
+func (c *CubieCube) SetUDSlice(n int) {
+	c.SetSliceSorted(n * sliceEdgePermutationSize)
+}
+
+// SliceSorted returns the positions and the order of the FR, FL, BL and BR
+// edges from 0 to 11879. This is less than 24 when the edges are between the U
+// and D faces.
+func (c CubieCube) SliceSorted() int {
+	a, x := 0, 0
+	var edges [4]byte
+	for j := 11; j >= 0; j-- {
+		if c.EP[j] >= sliceEdge {
+			a += binomial(11-j, x+1)
+			edges[3-x] = c.EP[j] - sliceEdge
+			x++
+		}
+	}
+	return sliceEdgePermutationSize*a + permutationCoordinate(edges[:])
+}
+
+// SetSliceSorted puts the FR, FL, BL and BR edges in the positions and order
+// from the coordinate n, see SliceSorted. The other edges fill the remaining
+// positions in order.
+func (c *CubieCube) SetSliceSorted(n int) {
+	var edges [4]byte
+	setPermutationCoordinate(edges[:], n%sliceEdgePermutationSize)
+	a := n / sliceEdgePermutationSize
+
+	var used [12]bool
+	x := 4
+	for j := 0; j < 12 && x > 0; j++ {
+		if b := binomial(11-j, x); a >= b {
+			c.EP[j] = edges[4-x] + sliceEdge
+			used[j] = true
+			a -= b
+			x--
+		}
+	}
+	other := byte(0)
+	for j := range c.EP {
+		if !used[j] {
+			c.EP[j] = other
+			other++
+		}
+	}
+}
+
+// CornerPermutation returns the permutation of the corners from 0 to 40319.
+func (c CubieCube) CornerPermutation() int {
+	return permutationCoordinate(c.CP[:])
+}
+
+// SetCornerPermutation sets the permutation of the corners from the
+// coordinate n, see CornerPermutation.
+func (c *CubieCube) SetCornerPermutation(n int) {
+	setPermutationCoordinate(c.CP[:], n)
+}
+
+// EdgePermutation returns the permutation of all twelve edges from 0 to
+// 479001599.
+func (c CubieCube) EdgePermutation() int {
+	return permutationCoordinate(c.EP[:])
+}
+
+// SetEdgePermutation sets the permutation of the edges from the coordinate n,
+// see EdgePermutation.
+func (c *CubieCube) SetEdgePermutation(n int) {
+	setPermutationCoordinate(c.EP[:], n)
+}
+
+// UDEdgePermutation returns the order of the eight edges of the U and D faces
+// from 0 to 40319, taken in the order of the positions holding them. When the
+// FR, FL, BL and BR edges are between the U and D faces this is the
+// permutation of the first eight positions.
+func (c CubieCube) UDEdgePermutation() int {
+	var edges [sliceEdge]byte
+	n := 0
+	for _, e := range c.EP {
+		if e < sliceEdge {
+			edges[n] = e
+			n++
+		}
+	}
+	return permutationCoordinate(edges[:])
+}
+
+// SetUDEdgePermutation puts the eight edges of the U and D faces in the order
+// from the coordinate n, see UDEdgePermutation. The positions of the FR, FL, BL
+// and BR edges are not changed.
+func (c *CubieCube) SetUDEdgePermutation(n int) {
+	var edges [sliceEdge]byte
+	setPermutationCoordinate(edges[:], n)
+	i := 0
+	for j, e := range c.EP {
+		if e < sliceEdge {
+			c.EP[j] = edges[i]
+			i++
+		}
+	}
+}
+
+// permutationCoordinate numbers the permutation p of the values 0 to len(p)-1
+// by counting the rotations needed to move each value into place, starting
+// from the last.
+func permutationCoordinate(p []byte) int {
+	perm := make([]byte, len(p))
+	copy(perm, p)
+	n := 0
+	for j := len(perm) - 1; j > 0; j-- {
+		k := 0
+		for int(perm[j]) != j && k <= j {
+			rotateLeft(perm[:j+1])
+			k++
+		}
+		n = (j+1)*n + k
+	}
+	return n
+}
+
+// setPermutationCoordinate is the reverse of permutationCoordinate.
+func setPermutationCoordinate(p []byte, n int) {
+	for i := range p {
+		p[i] = byte(i)
+	}
+	for j := 1; j < len(p); j++ {
+		k := n % (j + 1)
+		n /= j + 1
+		for ; k > 0; k-- {
+			rotateRight(p[:j+1])
+		}
+	}
+}
+
+func rotateLeft(p []byte) {
+	first := p[0]
+	copy(p, p[1:])
+	p[len(p)-1] = first
+}
+
+func rotateRight(p []byte) {
+	last := p[len(p)-1]
+	copy(p[1:], p)
+	p[0] = last
+}
+
+// binomial returns n choose k, or 0 if k is larger than n.
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	z := 1
+	for i := 0; i < k; i++ {
+		z = z * (n - i) / (i + 1)
+	}
+	return z
+}
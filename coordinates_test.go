@@ -0,0 +1,82 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCubieCube_Coordinates(t *testing.T) {
+	c := NewSolvedCubieCube()
+	assert.Equal(t, 0, c.Twist())
+	assert.Equal(t, 0, c.Flip())
+	assert.Equal(t, 0, c.UDSlice())
+	assert.Equal(t, 0, c.SliceSorted())
+	assert.Equal(t, 0, c.CornerPermutation())
+	assert.Equal(t, 0, c.EdgePermutation())
+	assert.Equal(t, 0, c.UDEdgePermutation())
+
+	// values from Kociemba's two phase solver
+	r := NewSolvedCube().Move(Right).CubieCube()
+	assert.Equal(t, 1494, r.Twist())
+	assert.Equal(t, 0, r.Flip())
+	assert.Equal(t, 26692, r.CornerPermutation())
+	f := NewSolvedCube().Move(Front).CubieCube()
+	assert.Equal(t, 550, f.Flip())
+}
+
+func TestCubieCube_SetCoordinates(t *testing.T) {
+	for _, i := range []struct {
+		count int
+		get   func(CubieCube) int
+		set   func(*CubieCube, int)
+	}{
+		{TwistCount, CubieCube.Twist, (*CubieCube).SetTwist},
+		{FlipCount, CubieCube.Flip, (*CubieCube).SetFlip},
+		{UDSliceCount, CubieCube.UDSlice, (*CubieCube).SetUDSlice},
+		{SliceSortedCount, CubieCube.SliceSorted, (*CubieCube).SetSliceSorted},
+		{CornerPermutationCount, CubieCube.CornerPermutation, (*CubieCube).SetCornerPermutation},
+		{UDEdgePermutationCount, CubieCube.UDEdgePermutation, (*CubieCube).SetUDEdgePermutation},
+	} {
+		for n := 0; n < i.count; n++ {
+			c := NewSolvedCubieCube()
+			i.set(&c, n)
+			assert.Equal(t, n, i.get(c))
+			if t.Failed() {
+				return
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		n := r.Intn(EdgePermutationCount)
+		c := NewSolvedCubieCube()
+		c.SetEdgePermutation(n)
+		assert.Equal(t, n, c.EdgePermutation())
+	}
+}
+
+func TestCubieCube_Coordinates_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= Left2; m++ {
+		all = append(all, m)
+	}
+	for i := 0; i < 100; i++ {
+		c := NewSolvedCube().Apply(randomAlgorithm(r, all, 30)).CubieCube()
+		assert.Less(t, c.Twist(), TwistCount)
+		assert.Equal(t, c.SliceSorted()/24, c.UDSlice())
+
+		var z CubieCube
+		z.SetTwist(c.Twist())
+		z.SetFlip(c.Flip())
+		z.SetCornerPermutation(c.CornerPermutation())
+		z.SetEdgePermutation(c.EdgePermutation())
+		assert.Equal(t, c, z)
+
+		z.SetSliceSorted(c.SliceSorted())
+		assert.Equal(t, c.SliceSorted(), z.SliceSorted())
+		assert.Equal(t, c.Flip(), z.Flip())
+	}
+}
@@ -1,6 +1,9 @@
 package rubiks_cube
 
 // CornerCubelet stores the type and rotation of a cubelet. Only 5 bits are used.
+//
+// The rotation is the direction which the white/yellow side of the corner is
+// pointing.
 type CornerCubelet byte
 
 func MakeCornerCubelet(cType CornerType, cFace Facing) CornerCubelet {
@@ -24,31 +27,82 @@ func (c CornerCubelet) Turn(t TurnOfCubelet) CornerCubelet {
 	return MakeCornerCubelet(c.Piece(), z)
 }
 
-func (c CornerCubelet) GetColor(f Facing) Color {
-	z := c.Piece()
+// Twist returns the number of times the corner in a position with the
+// handedness p has been twisted clockwise away from the rotation where the
+// white/yellow side faces up or down.
+func (c CornerCubelet) Twist(p CornerPosition) byte {
 	switch c.Rotation() {
-	case FacingUpDown:
-		return z.GetColor(f)
 	case FacingFrontBack:
-		switch f {
-		case FacingUpDown:
-			return z.GetColor(FacingFrontBack)
-		case FacingFrontBack:
-			return z.GetColor(FacingUpDown)
-		case FacingRightLeft:
-			return z.GetColor(FacingRightLeft)
+		if p == CornerAntiClockwise {
+			return 2
 		}
+		return 1
 	case FacingRightLeft:
-		switch f {
-		case FacingUpDown:
-			return z.GetColor(FacingFrontBack)
-		case FacingFrontBack:
-			return z.GetColor(FacingRightLeft)
-		case FacingRightLeft:
-			return z.GetColor(FacingUpDown)
+		if p == CornerAntiClockwise {
+			return 1
 		}
+		return 2
 	}
-	return 255
+	return 0
+}
+
+// GetColor returns the color shown on the face f of the cubelet when it is in
+// a position with the same handedness as its solved position.
+func (c CornerCubelet) GetColor(f Facing) Color {
+	return c.colorAt(c.Piece().Position(), f)
+}
+
+// colorAt returns the color shown on the face f of the cubelet when it is in a
+// position with the handedness p.
+func (c CornerCubelet) colorAt(p CornerPosition, f Facing) Color {
+	z := c.Piece()
+	if p == z.Position() {
+		return z.GetColor(cornerSameHandTable[c.Rotation()][f])
+	}
+	return z.GetColor(cornerOtherHandTable[c.Rotation()][f])
+}
+
+// cornerSameHandTable maps the rotation of a corner and the facing of a face to
+// the facing of the corner type shown on that face. This is used when the
+// corner is in a position of the same handedness as its solved position, which
+// only allows the corner to be twisted.
+var cornerSameHandTable = [][]Facing{
+	FacingUpDown: {
+		FacingUpDown:    FacingUpDown,
+		FacingFrontBack: FacingFrontBack,
+		FacingRightLeft: FacingRightLeft,
+	},
+	FacingFrontBack: {
+		FacingUpDown:    FacingRightLeft,
+		FacingFrontBack: FacingUpDown,
+		FacingRightLeft: FacingFrontBack,
+	},
+	FacingRightLeft: {
+		FacingUpDown:    FacingFrontBack,
+		FacingFrontBack: FacingRightLeft,
+		FacingRightLeft: FacingUpDown,
+	},
+}
+
+// cornerOtherHandTable is the same as cornerSameHandTable but for a corner in a
+// position of the opposite handedness to its solved position, where the sides
+// not facing the rotation are mirrored.
+var cornerOtherHandTable = [][]Facing{
+	FacingUpDown: {
+		FacingUpDown:    FacingUpDown,
+		FacingFrontBack: FacingRightLeft,
+		FacingRightLeft: FacingFrontBack,
+	},
+	FacingFrontBack: {
+		FacingUpDown:    FacingFrontBack,
+		FacingFrontBack: FacingUpDown,
+		FacingRightLeft: FacingRightLeft,
+	},
+	FacingRightLeft: {
+		FacingUpDown:    FacingRightLeft,
+		FacingFrontBack: FacingFrontBack,
+		FacingRightLeft: FacingUpDown,
+	},
 }
 
 type CornerType byte
@@ -75,10 +129,26 @@ var cornerColorTable = [][3]Color{
 	{Yellow, Orange, Blue},
 }
 
+var cornerPositionTable = []CornerPosition{
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+}
+
 func (t CornerType) Valid() bool {
 	return t <= CornerYellowOrangeBlue
 }
 
+// Position returns the handedness of the solved position of the corner type.
+func (t CornerType) Position() CornerPosition {
+	return cornerPositionTable[t]
+}
+
 func (t CornerType) GetColor(f Facing) Color {
 	return cornerColorTable[t][f]
 }
@@ -88,18 +158,24 @@ func (t CornerType) String() string {
 	return "Corner" + z[0].String() + z[1].String() + z[2].String()
 }
 
+// DetectCorner finds the corner cubelet showing the colors up, front and right
+// in a position of either handedness.
 func DetectCorner(up, front, right Color) CornerCubelet {
-	upFrontRight := [3]Color{up, front, right}
-	frontRightUp := [3]Color{front, right, up}
-	rightFrontUp := [3]Color{right, up, front}
-	for i, j := range cornerColorTable {
-		switch j {
-		case upFrontRight:
-			return MakeCornerCubelet(CornerType(i), FacingUpDown)
-		case frontRightUp:
-			return MakeCornerCubelet(CornerType(i), FacingFrontBack)
-		case rightFrontUp:
-			return MakeCornerCubelet(CornerType(i), FacingRightLeft)
+	if c := detectCorner(CornerAntiClockwise, up, front, right); c.Valid() {
+		return c
+	}
+	return detectCorner(CornerClockwise, up, front, right)
+}
+
+// detectCorner finds the corner cubelet showing the colors up, front and right
+// in a position with the handedness p.
+func detectCorner(p CornerPosition, up, front, right Color) CornerCubelet {
+	for i := CornerWhiteOrangeGreen; i <= CornerYellowOrangeBlue; i++ {
+		for j := FacingUpDown; j <= FacingRightLeft; j++ {
+			c := MakeCornerCubelet(i, j)
+			if c.colorAt(p, FacingUpDown) == up && c.colorAt(p, FacingFrontBack) == front && c.colorAt(p, FacingRightLeft) == right {
+				return c
+			}
 		}
 	}
 	return 255
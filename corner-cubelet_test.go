@@ -0,0 +1,24 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDetectCorner(t *testing.T) {
+	for i := CornerWhiteOrangeGreen; i <= CornerYellowOrangeBlue; i++ {
+		for j := FacingUpDown; j <= FacingRightLeft; j++ {
+			c := MakeCornerCubelet(i, j)
+			assert.Equal(t, c, DetectCorner(c.GetColor(FacingUpDown), c.GetColor(FacingFrontBack), c.GetColor(FacingRightLeft)))
+
+			// the colors read the other way round only fit a position of the
+			// other handedness
+			other := i.Position() ^ 1
+			up, front, right := c.colorAt(other, FacingUpDown), c.colorAt(other, FacingFrontBack), c.colorAt(other, FacingRightLeft)
+			assert.Equal(t, c, DetectCorner(up, front, right))
+			assert.Equal(t, c, detectCorner(other, up, front, right))
+			assert.False(t, detectCorner(i.Position(), up, front, right).Valid())
+		}
+	}
+	assert.False(t, DetectCorner(White, Yellow, Green).Valid())
+}
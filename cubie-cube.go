@@ -0,0 +1,176 @@
+package rubiks_cube
+
+// CubieCube stores the state of a cube as the permutation and orientation of
+// the corners and edges, the representation used by Kociemba and most of the
+// literature on solving the cube.
+//
+// The corner positions are URF, UFL, ULB, UBR, DFR, DLF, DBL, DRB and the edge
+// positions are UR, UF, UL, UB, DR, DF, DL, DB, FR, FL, BL, BR. CP and EP hold
+// the index of the piece in each position, where each piece is numbered by
+// its solved position. CO is the number of clockwise twists of each corner and
+// EO is 1 for each flipped edge.
+//
+// The faces are named for the standard orientation of RubiksCube, so U is the
+// white center and F is the orange center. The way the cube is held is not
+// part of a CubieCube.
+type CubieCube struct {
+	CP [8]byte
+	CO [8]byte
+	EP [12]byte
+	EO [12]byte
+}
+
+// NewSolvedCubieCube forms the CubieCube of a solved cube.
+func NewSolvedCubieCube() CubieCube {
+	var c CubieCube
+	for i := range c.CP {
+		c.CP[i] = byte(i)
+	}
+	for i := range c.EP {
+		c.EP[i] = byte(i)
+	}
+	return c
+}
+
+// cubieCornerSlotTable and cubieEdgeSlotTable are the index in
+// RubiksCube.corners and RubiksCube.edges of each position of a CubieCube.
+var (
+	cubieCornerSlotTable = [8]int{0, 4, 5, 1, 3, 7, 6, 2}
+	cubieEdgeSlotTable   = [12]int{0, 8, 4, 9, 2, 11, 6, 10, 3, 7, 5, 1}
+)
+
+// cubieCornerTable and cubieEdgeTable are the index used by a CubieCube for
+// each CornerType and EdgeType.
+var cubieCornerTable, cubieEdgeTable = func() (corners [8]byte, edges [12]byte) {
+	for i, slot := range cubieCornerSlotTable {
+		for piece, home := range cornerHomeTable {
+			if home == slot {
+				corners[piece] = byte(i)
+			}
+		}
+	}
+	for i, slot := range cubieEdgeSlotTable {
+		for piece, home := range edgeHomeTable {
+			if home == slot {
+				edges[piece] = byte(i)
+			}
+		}
+	}
+	return
+}()
+
+// CubieCube converts the cube into a CubieCube. The orientation which the cube
+// is held in is dropped, use RubiksCube.Hold to restore it after converting
+// back with CubieCube.RubiksCube.
+func (r RubiksCube) CubieCube() CubieCube {
+	var c CubieCube
+	corners := r.corners()
+	for i, slot := range cubieCornerSlotTable {
+		corner := corners[slot]
+		c.CP[i] = cubieCornerTable[corner.Piece()]
+		c.CO[i] = corner.Twist(cornerSlotPositionTable[slot])
+	}
+	edges := r.edges()
+	for i, slot := range cubieEdgeSlotTable {
+		edge := edges[slot]
+		c.EP[i] = cubieEdgeTable[edge.Piece()]
+		c.EO[i] = byte(edge.Rotation())
+	}
+	return c
+}
+
+// RubiksCube converts the CubieCube back into a RubiksCube held in the
+// StandardOrientation. Values which are out of range produce invalid cubelets
+// which are reported by RubiksCube.Validate.
+func (c CubieCube) RubiksCube() RubiksCube {
+	var corners [8]CornerCubelet
+	for i, slot := range cubieCornerSlotTable {
+		corners[slot] = 255
+		if c.CP[i] < 8 && c.CO[i] < 3 {
+			corners[slot] = MakeCornerCubelet(cubieCornerPiece(c.CP[i]), twistFacingTable[cornerSlotPositionTable[slot]][c.CO[i]])
+		}
+	}
+	var edges [12]EdgeCubelet
+	for i, slot := range cubieEdgeSlotTable {
+		edges[slot] = 255
+		if c.EP[i] < 12 && c.EO[i] < 2 {
+			edges[slot] = MakeEdgeCubelet(cubieEdgePiece(c.EP[i]), EdgeFacing(c.EO[i]))
+		}
+	}
+
+	var r RubiksCube
+	r.setCorners(corners)
+	r.setEdges(edges)
+	r.Orientation = StandardOrientation
+	return r
+}
+
+// cubieCornerPiece and cubieEdgePiece are the reverse of cubieCornerTable and
+// cubieEdgeTable.
+func cubieCornerPiece(i byte) CornerType {
+	for piece, j := range cubieCornerTable {
+		if j == i {
+			return CornerType(piece)
+		}
+	}
+	return 255
+}
+
+func cubieEdgePiece(i byte) EdgeType {
+	for piece, j := range cubieEdgeTable {
+		if j == i {
+			return EdgeType(piece)
+		}
+	}
+	return 255
+}
+
+// twistFacingTable is the reverse of CornerCubelet.Twist for each handedness
+// of corner position.
+var twistFacingTable = [2][3]Facing{
+	CornerAntiClockwise: {FacingUpDown, FacingRightLeft, FacingFrontBack},
+	CornerClockwise:     {FacingUpDown, FacingFrontBack, FacingRightLeft},
+}
+
+// Multiply returns the state of a cube in the state c after the pieces are
+// moved in the same way as d moves them from the solved state.
+func (c CubieCube) Multiply(d CubieCube) CubieCube {
+	var z CubieCube
+	for i, j := range d.CP {
+		z.CP[i] = c.CP[j]
+		z.CO[i] = (c.CO[j] + d.CO[i]) % 3
+	}
+	for i, j := range d.EP {
+		z.EP[i] = c.EP[j]
+		z.EO[i] = (c.EO[j] + d.EO[i]) % 2
+	}
+	return z
+}
+
+// Inverse returns the state which moves the pieces back to where they were
+// before c, so c.Multiply(c.Inverse()) is solved.
+func (c CubieCube) Inverse() CubieCube {
+	var z CubieCube
+	for i, j := range c.CP {
+		z.CP[j] = byte(i)
+		z.CO[j] = (3 - c.CO[i]) % 3
+	}
+	for i, j := range c.EP {
+		z.EP[j] = byte(i)
+		z.EO[j] = c.EO[i]
+	}
+	return z
+}
+
+// IsSolved returns true if every piece is in its solved position without any
+// twist or flip.
+func (c CubieCube) IsSolved() bool {
+	return c == NewSolvedCubieCube()
+}
+
+// rotationCubie returns the CubieCube which moves the pieces the same way as
+// turning the whole cube from the standard orientation to the orientation o.
+func rotationCubie(o Orientation) CubieCube {
+	cube, _ := detectCubelets(NewSolvedCube().Hold(o).faces())
+	return cube.CubieCube()
+}
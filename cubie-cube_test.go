@@ -0,0 +1,45 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRubiksCube_CubieCube(t *testing.T) {
+	assert.Equal(t, NewSolvedCubieCube(), NewSolvedCube().CubieCube())
+	assert.Equal(t, NewSolvedCube(), NewSolvedCubieCube().RubiksCube())
+
+	// the basic moves from Kociemba's CubieCube
+	assert.Equal(t, CubieCube{
+		CP: [8]byte{4, 1, 2, 0, 7, 5, 6, 3},
+		CO: [8]byte{2, 0, 0, 1, 1, 0, 0, 2},
+		EP: [12]byte{8, 1, 2, 3, 11, 5, 6, 7, 4, 9, 10, 0},
+	}, NewSolvedCube().Move(Right).CubieCube())
+	assert.Equal(t, CubieCube{
+		CP: [8]byte{1, 5, 2, 3, 0, 4, 6, 7},
+		CO: [8]byte{1, 2, 0, 0, 2, 1, 0, 0},
+		EP: [12]byte{0, 9, 2, 3, 4, 8, 6, 7, 1, 5, 10, 11},
+		EO: [12]byte{0, 1, 0, 0, 0, 1, 0, 0, 1, 1, 0, 0},
+	}, NewSolvedCube().Move(Front).CubieCube())
+	assert.Equal(t, CubieCube{
+		CP: [8]byte{3, 0, 1, 2, 4, 5, 6, 7},
+		EP: [12]byte{3, 0, 1, 2, 4, 5, 6, 7, 8, 9, 10, 11},
+	}, NewSolvedCube().Move(Up).CubieCube())
+}
+
+func TestCubieCube_RubiksCube(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= LeftWide2; m++ {
+		all = append(all, m)
+	}
+	for i := 0; i < 100; i++ {
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 30))
+		assert.Equal(t, cube, cube.CubieCube().RubiksCube().Hold(cube.Orientation))
+	}
+
+	c := NewSolvedCubieCube()
+	c.CO[0] = 3
+	assert.ErrorIs(t, c.RubiksCube().Validate(), ErrInvalidCubeState)
+}
@@ -0,0 +1,157 @@
+package rubiks_cube
+
+import (
+	"strings"
+)
+
+// Cycle is a cycle of corner or edge positions of a CubieCube. The piece in
+// each position moves to the next position and the piece in the last position
+// moves to the first. Twist is the total number of clockwise twists of the
+// corners, or the total number of flips of the edges, made by the cycle.
+type Cycle struct {
+	Edge      bool
+	Positions []byte
+	Twist     byte
+}
+
+// String returns the cycle in Singmaster notation, for example "(UF UR UL)".
+// A "+" or "-" is added for a cycle which twists the corners clockwise or
+// anti-clockwise and a "+" is added for a cycle which flips the edges, so a
+// single twisted corner is "(UFR+)".
+func (c Cycle) String() string {
+	var s strings.Builder
+	s.WriteByte('(')
+	for i, p := range c.Positions {
+		if i > 0 {
+			s.WriteByte(' ')
+		}
+		if c.Edge {
+			s.WriteString(EdgeSlot(cubieEdgeSlotTable[p]).String())
+		} else {
+			s.WriteString(CornerSlot(cubieCornerSlotTable[p]).String())
+		}
+	}
+	switch {
+	case c.Twist == 0:
+	case c.Twist == 2 && !c.Edge:
+		s.WriteByte('-')
+	default:
+		s.WriteByte('+')
+	}
+	s.WriteByte(')')
+	return s.String()
+}
+
+// order is the number of times the cycle is repeated before every piece is
+// back in the same position without any twist or flip.
+func (c Cycle) order() int {
+	n := len(c.Positions)
+	switch {
+	case c.Twist == 0:
+		return n
+	case c.Edge:
+		return n * 2
+	}
+	return n * 3
+}
+
+// Cycles is the list of cycles which make up a cube state.
+type Cycles []Cycle
+
+func (c Cycles) String() string {
+	var s strings.Builder
+	for _, i := range c {
+		s.WriteString(i.String())
+	}
+	return s.String()
+}
+
+// Cycles splits the pieces which are not solved into cycles, with the edges
+// before the corners. Pieces which are twisted or flipped in their solved
+// position are cycles of a single position. The positions are named by the
+// faces of the standard orientation, so whole cube rotations are ignored.
+func (r RubiksCube) Cycles() Cycles {
+	return r.CubieCube().Cycles()
+}
+
+// Cycles splits the pieces which are not solved into cycles, see
+// RubiksCube.Cycles.
+func (c CubieCube) Cycles() Cycles {
+	inv := c.Inverse()
+	var z Cycles
+	z = appendCycles(z, true, inv.EP[:], c.EO[:], 2)
+	z = appendCycles(z, false, inv.CP[:], c.CO[:], 3)
+	return z
+}
+
+// appendCycles adds the cycles of the positions where the piece in each
+// position i moves to next[i] and has the orientation given by ori once it
+// has moved.
+func appendCycles(z Cycles, edge bool, next, ori []byte, mod byte) Cycles {
+	seen := make([]bool, len(next))
+	for i := range next {
+		if seen[i] {
+			continue
+		}
+		c := Cycle{Edge: edge}
+		for j := byte(i); !seen[j]; j = next[j] {
+			seen[j] = true
+			c.Positions = append(c.Positions, j)
+			c.Twist = (c.Twist + ori[j]) % mod
+		}
+		if len(c.Positions) > 1 || c.Twist != 0 {
+			z = append(z, c)
+		}
+	}
+	return z
+}
+
+// Parity returns true if the corners, and so also the edges of a valid cube,
+// have been moved by an odd number of swaps.
+func (r RubiksCube) Parity() bool {
+	corners := r.CubieCube().CP
+	p := make([]int, len(corners))
+	for i, j := range corners {
+		p[i] = int(j)
+	}
+	return oddPermutation(p)
+}
+
+// Order returns the number of times the moves that made r from a solved cube
+// must be repeated to return to a solved cube held in the standard
+// orientation.
+func (r RubiksCube) Order() int {
+	// repeat until the cube is held the same way, then only the cycles of
+	// the pieces matter
+	n := 1
+	z := r
+	for z.Orientation != StandardOrientation {
+		z = z.Compose(r)
+		n++
+	}
+	order := 1
+	for _, c := range z.Cycles() {
+		order = lcm(order, c.order())
+	}
+	return n * order
+}
+
+// Cycles returns the cycles of the pieces moved by the algorithm, see
+// RubiksCube.Cycles.
+func (a Algorithm) Cycles() Cycles {
+	return NewSolvedCube().Apply(a).Cycles()
+}
+
+// Order returns the number of times the algorithm must be repeated to return a
+// cube to the state it started in.
+func (a Algorithm) Order() int {
+	return NewSolvedCube().Apply(a).Order()
+}
+
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
@@ -0,0 +1,59 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAlgorithm_Cycles(t *testing.T) {
+	for _, i := range [][2]string{
+		{"", ""},
+		{"U", "(UR UF UL UB)(UFR UFL UBL UBR)"},
+		{"U'", "(UR UB UL UF)(UFR UBR UBL UFL)"},
+		{"F", "(UF FR DF FL)(UFR DFR DFL UFL)"},
+		{"R U R' U'", "(UR UB FR)(UFR DFR+)(UBL UBR-)"},
+		{"R U' R U R U R U' R' U' R2", "(UR UL UF)"},
+		{"(R' D' R D)2 U (R' D' R D)4 U'", "(UFR-)(UBR+)"},
+		{"(M' U)4 (M U)4", "(UR+)(UF+)(UL+)(UB+)"},
+		{"M2 U M2 U2 M2 U M2", "(UR UL)(UF UB)"},
+		{"x y", ""},
+	} {
+		a := mustParseAlgorithm(t, i[0])
+		assert.Equal(t, i[1], a.Cycles().String(), i[0])
+	}
+}
+
+func TestAlgorithm_Order(t *testing.T) {
+	for _, i := range []struct {
+		alg   string
+		order int
+	}{
+		{"", 1},
+		{"R", 4},
+		{"U2", 2},
+		{"R U R' U'", 6},
+		{"R U", 105},
+		{"R U2 D' B D'", 1260},
+		{"R U R' U R U2 R'", 6},
+		{"x", 4},
+		{"M", 4},
+		{"R x", 4},
+	} {
+		a := mustParseAlgorithm(t, i.alg)
+		assert.Equal(t, i.order, a.Order(), i.alg)
+
+		cube := NewSolvedCube()
+		for j := 0; j < i.order; j++ {
+			cube = cube.Apply(a)
+		}
+		assert.True(t, cube.IsSolved(), i.alg)
+		assert.Equal(t, StandardOrientation, cube.Orientation, i.alg)
+	}
+}
+
+func TestRubiksCube_Parity(t *testing.T) {
+	assert.False(t, NewSolvedCube().Parity())
+	assert.True(t, NewSolvedCube().Move(Up).Parity())
+	assert.False(t, NewSolvedCube().Apply(mustParseAlgorithm(t, "R U R' U R U2 R'")).Parity())
+	assert.True(t, NewSolvedCube().Apply(mustParseAlgorithm(t, "R U R' U' R' F R2 U' R' U' R U R' F'")).Parity())
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -19,25 +21,63 @@ type FaceData [9]Color
 
 var ErrInvalidCubeString = errors.New("invalid cube string")
 
+// Facelet is a single sticker of a cube. Index counts from 0 to 8 across each
+// row of the face as shown by RubiksCube.Face.
+type Facelet struct {
+	Face  Face
+	Index int
+}
+
+func (f Facelet) String() string {
+	return faceNotationTable[f.Face] + strconv.Itoa(f.Index)
+}
+
+// FaceletError describes the character where a cube diagram failed to parse.
+// Line and Column both start at 1. Char is 0 if the line or the diagram ended
+// too early. Facelet is the sticker at that position, or has an Index of -1 if
+// the position is not part of a face.
+type FaceletError struct {
+	Line    int
+	Column  int
+	Char    rune
+	Facelet Facelet
+}
+
+func (e *FaceletError) Error() string {
+	var s string
+	if e.Char == 0 {
+		s = fmt.Sprintf("%d:%d: %s: unexpected end of line", e.Line, e.Column, ErrInvalidCubeString)
+	} else {
+		s = fmt.Sprintf("%d:%d: %s %q", e.Line, e.Column, ErrInvalidCubeString, e.Char)
+	}
+	if e.Facelet.Index >= 0 {
+		s += " at facelet " + e.Facelet.String()
+	}
+	return s
+}
+
+func (e *FaceletError) Unwrap() error {
+	return ErrInvalidCubeString
+}
+
 func ParseFaces(v string) (CubeFaceData, error) {
 	var faces CubeFaceData
 
 	scanner := bufio.NewScanner(strings.NewReader(v))
 	i := 0
 	for i < 9 && scanner.Scan() {
-		fmt.Println(i)
 		line := scanner.Text()
 		switch {
 		case i < 3:
 			if !parseTopRegex.MatchString(line) {
-				return faces, ErrInvalidCubeString
+				return faces, faceletErrorAt(i, line)
 			}
 			faces[FaceUp][i*3] = ParseColor(line[3])
 			faces[FaceUp][i*3+1] = ParseColor(line[4])
 			faces[FaceUp][i*3+2] = ParseColor(line[5])
 		case i >= 3 && i < 6:
 			if !parseMiddleRegex.MatchString(line) {
-				return faces, ErrInvalidCubeString
+				return faces, faceletErrorAt(i, line)
 			}
 			j := i - 3
 			faces[FaceLeft][j*3] = ParseColor(line[0])
@@ -54,16 +94,71 @@ func ParseFaces(v string) (CubeFaceData, error) {
 			faces[FaceBack][j*3+2] = ParseColor(line[11])
 		case i >= 6 && i < 9:
 			if !parseTopRegex.MatchString(line) {
-				return faces, ErrInvalidCubeString
+				return faces, faceletErrorAt(i, line)
 			}
 			j := i - 6
 			faces[FaceDown][j*3] = ParseColor(line[3])
 			faces[FaceDown][j*3+1] = ParseColor(line[4])
 			faces[FaceDown][j*3+2] = ParseColor(line[5])
-		default:
-			return faces, ErrInvalidCubeString
 		}
 		i++
 	}
-	return faces, scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return faces, err
+	}
+	if i < 9 {
+		return faces, faceletErrorAt(i, "")
+	}
+	return faces, nil
 }
+
+// faceletErrorAt finds the first character which does not fit the layout of
+// line i of a cube diagram.
+func faceletErrorAt(i int, line string) *FaceletError {
+	width := 6
+	if i >= 3 && i < 6 || len(line) > 6 {
+		width = 12
+	}
+	for col := 0; col <= width; col++ {
+		f, ok := diagramFacelet(i, col)
+		if !ok {
+			f.Index = -1
+		}
+		var c rune
+		if col < len(line) {
+			c, _ = utf8.DecodeRuneInString(line[col:])
+		}
+		switch {
+		case col == width && c == 0:
+			continue
+		case col == width, c == 0, ok && ParseColor(line[col]) == UnknownColor, !ok && c != ' ':
+		default:
+			continue
+		}
+		return &FaceletError{
+			Line:    i + 1,
+			Column:  utf8.RuneCountInString(line[:min(col, len(line))]) + 1,
+			Char:    c,
+			Facelet: f,
+		}
+	}
+	return nil
+}
+
+// diagramFacelet returns the facelet shown at column col of line i of a cube
+// diagram, both starting at 0.
+func diagramFacelet(i, col int) (Facelet, bool) {
+	switch {
+	case i < 0 || i >= 9 || col < 0 || col >= 12:
+		return Facelet{}, false
+	case i >= 3 && i < 6:
+		return Facelet{diagramMiddleTable[col/3], (i-3)*3 + col%3}, true
+	case col < 3 || col >= 6:
+		return Facelet{}, false
+	case i < 3:
+		return Facelet{FaceUp, i*3 + col - 3}, true
+	}
+	return Facelet{FaceDown, (i-6)*3 + col - 3}, true
+}
+
+var diagramMiddleTable = [4]Face{FaceLeft, FaceFront, FaceRight, FaceBack}
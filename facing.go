@@ -1,6 +1,6 @@
 package rubiks_cube
 
-//go:generate stringer -type Facing,EdgeFacing,EdgePosition
+//go:generate stringer -type Facing,EdgeFacing,EdgePosition,CornerPosition
 
 type Facing byte
 
@@ -126,3 +126,16 @@ var edgeStateOfTable = [][]EdgeFacing{
 func (p EdgePosition) StateOf(f Facing) EdgeFacing {
 	return edgeStateOfTable[p][f]
 }
+
+// CornerPosition is the handedness of a corner position. Reading the up/down,
+// front/back and right/left faces of a position in that order goes
+// anti-clockwise around a CornerAntiClockwise position, like the top front
+// right corner, and clockwise around a CornerClockwise position, like the top
+// back right corner. Every quarter turn moves a corner into a position of the
+// other handedness.
+type CornerPosition byte
+
+const (
+	CornerAntiClockwise CornerPosition = iota
+	CornerClockwise
+)
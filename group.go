@@ -0,0 +1,28 @@
+package rubiks_cube
+
+// Compose returns the cube after the pieces of r are moved in the same way as
+// other moves them from a solved cube. If other is the result of applying an
+// algorithm to a solved cube then this is the same as applying the algorithm
+// to r, including the whole cube rotations.
+func (r RubiksCube) Compose(other RubiksCube) RubiksCube {
+	// moves are made relative to the way r is held, so the pieces of other
+	// are turned into that orientation first
+	rot := orientationCubies[r.Orientation]
+	moves := rot.Multiply(other.CubieCube()).Multiply(rot.Inverse())
+	return r.CubieCube().Multiply(moves).RubiksCube().Hold(r.Orientation.Compose(other.Orientation))
+}
+
+// Inverse returns the cube which undoes the moves that made r from a solved
+// cube, so r.Compose(r.Inverse()) is a solved cube in the standard
+// orientation.
+func (r RubiksCube) Inverse() RubiksCube {
+	rot := orientationCubies[r.Orientation]
+	z := rot.Inverse().Multiply(r.CubieCube().Inverse()).Multiply(rot)
+	return z.RubiksCube().Hold(r.Orientation.Inverse())
+}
+
+// IsSolved returns true if every face of the cube is a single color. The way
+// the cube is held does not matter.
+func (r RubiksCube) IsSolved() bool {
+	return r.Hold(StandardOrientation) == NewSolvedCube()
+}
@@ -0,0 +1,44 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRubiksCube_Compose(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= LeftWide2; m++ {
+		all = append(all, m)
+	}
+	for i := 0; i < 200; i++ {
+		a := randomAlgorithm(r, all, r.Intn(30))
+		b := randomAlgorithm(r, all, r.Intn(30))
+		cube := NewSolvedCube().Apply(a)
+		other := NewSolvedCube().Apply(b)
+
+		assert.Equal(t, cube.Apply(b), cube.Compose(other), a.String()+" / "+b.String())
+		assert.Equal(t, NewSolvedCube(), cube.Compose(cube.Inverse()), a.String())
+		assert.Equal(t, NewSolvedCube(), cube.Inverse().Compose(cube), a.String())
+		assert.Equal(t, NewSolvedCube().Apply(a.Inverse()), cube.Inverse(), a.String())
+		assert.Equal(t, cube, cube.Compose(NewSolvedCube()), a.String())
+		assert.Equal(t, cube, NewSolvedCube().Compose(cube), a.String())
+	}
+}
+
+func TestRubiksCube_IsSolved(t *testing.T) {
+	assert.True(t, NewSolvedCube().IsSolved())
+	assert.True(t, NewSolvedCube().Apply(mustParseAlgorithm(t, "x y M E2")).Apply(mustParseAlgorithm(t, "E2 M'")).IsSolved())
+	assert.False(t, NewSolvedCube().Move(Right).IsSolved())
+	assert.False(t, NewSolvedCube().Move(Middle).IsSolved())
+	assert.True(t, NewSolvedCube().Apply(mustParseAlgorithm(t, "(R U R' U')6")).IsSolved())
+}
+
+func TestCubieCube_Multiply(t *testing.T) {
+	r := NewSolvedCube().Move(Right).CubieCube()
+	u := NewSolvedCube().Move(Up).CubieCube()
+	assert.Equal(t, NewSolvedCube().Move(Right).Move(Up).CubieCube(), r.Multiply(u))
+	assert.True(t, r.Multiply(u).Multiply(r.Multiply(u).Inverse()).IsSolved())
+	assert.False(t, r.IsSolved())
+}
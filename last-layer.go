@@ -0,0 +1,246 @@
+package rubiks_cube
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrF2LUnsolved         = errors.New("first two layers are not solved")
+	ErrLastLayerUnoriented = errors.New("last layer is not oriented")
+)
+
+// LastLayerCase is a case of the last layer found by RecognizeOLL or
+// RecognizePLL.
+type LastLayerCase struct {
+	// Name is the usual name of the case, like "OLL 27" or "T-perm". It is
+	// empty if the stage is already done.
+	Name string
+
+	// PreAUF turns the U layer to line the case up for Algorithm.
+	PreAUF Algorithm
+
+	// Algorithm is the algorithm from the built in set for the case.
+	Algorithm Algorithm
+
+	// PostAUF turns the U layer after Algorithm to line it up with the first
+	// two layers. It is always empty for OLL.
+	PostAUF Algorithm
+}
+
+// Solution joins the turns of the U layer and the algorithm of the case.
+func (l LastLayerCase) Solution() Algorithm {
+	return Algorithm{}.Concat(l.PreAUF, l.Algorithm, l.PostAUF)
+}
+
+// upTurns are the turns of the U layer which may be needed before or after a
+// last layer algorithm, fewest moves first.
+var upTurns = []Algorithm{{}, {Up}, {Up2}, {UpPrime}}
+
+// IsF2LSolved returns true if the first two layers are solved, which are the D
+// layer and the middle layer as the cube is held.
+func (r RubiksCube) IsF2LSolved() bool {
+	down := r.Face(FaceDown)
+	for _, c := range down {
+		if c != down[4] {
+			return false
+		}
+	}
+	for _, f := range []Face{FaceFront, FaceRight, FaceBack, FaceLeft} {
+		face := r.Face(f)
+		for _, c := range face[3:] {
+			if c != face[4] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RecognizeOLL finds the OLL case of a cube with the first two layers solved,
+// whichever way the U layer is turned. The last layer is the U layer as the
+// cube is held. The cube is checked with RubiksCube.Validate first and
+// ErrF2LUnsolved is returned if the first two layers are not solved.
+func RecognizeOLL(cube RubiksCube) (LastLayerCase, error) {
+	if err := checkLastLayer(cube); err != nil {
+		return LastLayerCase{}, err
+	}
+	if lastLayerOriented(cube) {
+		return LastLayerCase{PreAUF: Algorithm{}, Algorithm: Algorithm{}, PostAUF: Algorithm{}}, nil
+	}
+	for _, auf := range upTurns {
+		for _, oll := range ollAlgorithms {
+			if lastLayerOriented(cube.Apply(auf.Concat(oll.algorithm))) {
+				return LastLayerCase{Name: oll.name, PreAUF: auf, Algorithm: oll.algorithm, PostAUF: Algorithm{}}, nil
+			}
+		}
+	}
+	// the built in set has every case a valid cube can have
+	panic("unreachable")
+}
+
+// RecognizePLL finds the PLL case of a cube with the first two layers solved
+// and the last layer oriented, whichever way the U layer is turned. The last
+// layer is the U layer as the cube is held. The cube is checked with
+// RubiksCube.Validate first, ErrF2LUnsolved is returned if the first two layers
+// are not solved and ErrLastLayerUnoriented if the last layer is not oriented.
+func RecognizePLL(cube RubiksCube) (LastLayerCase, error) {
+	if err := checkLastLayer(cube); err != nil {
+		return LastLayerCase{}, err
+	}
+	if !lastLayerOriented(cube) {
+		return LastLayerCase{}, ErrLastLayerUnoriented
+	}
+	for _, auf := range upTurns {
+		if cube.Apply(auf).IsSolved() {
+			return LastLayerCase{PreAUF: auf, Algorithm: Algorithm{}, PostAUF: Algorithm{}}, nil
+		}
+	}
+	for _, before := range upTurns {
+		for _, pll := range pllAlgorithms {
+			for _, after := range upTurns {
+				if cube.Apply(before.Concat(pll.algorithm, after)).IsSolved() {
+					return LastLayerCase{Name: pll.name, PreAUF: before, Algorithm: pll.algorithm, PostAUF: after}, nil
+				}
+			}
+		}
+	}
+	// the built in set has every case a valid cube can have
+	panic("unreachable")
+}
+
+// checkLastLayer returns an error if the cube is invalid or the first two
+// layers are not solved.
+func checkLastLayer(cube RubiksCube) error {
+	if err := cube.Validate(); err != nil {
+		return err
+	}
+	if !cube.IsF2LSolved() {
+		return ErrF2LUnsolved
+	}
+	return nil
+}
+
+// lastLayerOriented returns true if every facelet of the U face is the color
+// of its center.
+func lastLayerOriented(cube RubiksCube) bool {
+	up := cube.Face(FaceUp)
+	for _, c := range up {
+		if c != up[4] {
+			return false
+		}
+	}
+	return true
+}
+
+// lastLayerAlgorithm is an algorithm from the built in set which solves a
+// case of the last layer when the first two layers are solved.
+type lastLayerAlgorithm struct {
+	name      string
+	algorithm Algorithm
+}
+
+// ollAlgorithms orient the last layer, numbered as in the usual list of the
+// 57 OLL cases.
+var ollAlgorithms = func() []lastLayerAlgorithm {
+	algorithms := []string{
+		"R U2 R2 F R F' U2 R' F R F'",
+		"F R U R' U' F' f R U R' U' f'",
+		"f R U R' U' f' U' F R U R' U' F'",
+		"f R U R' U' f' U F R U R' U' F'",
+		"r' U2 R U R' U r",
+		"r U2 R' U' R U' r'",
+		"r U R' U R U2 r'",
+		"r' U' R U' R' U2 r",
+		"R U R' U' R' F R2 U R' U' F'",
+		"R U R' U R' F R F' R U2 R'",
+		"r U R' U R' F R F' R U2 r'",
+		"M' R' U' R U' R' U2 R U' R r'",
+		"F U R U' R2 F' R U R U' R'",
+		"R' F R U R' F' R F U' F'",
+		"r' U' r R' U' R U r' U r",
+		"r U r' R U R' U' r U' r'",
+		"F R' F' R2 r' U R U' R' U' M'",
+		"r U R' U R U2 r2 U' R U' R' U2 r",
+		"r' R U R U R' U' M' R' F R F'",
+		"r U R' U' M2 U R U' R' U' M'",
+		"R U2 R' U' R U R' U' R U' R'",
+		"R U2 R2 U' R2 U' R2 U2 R",
+		"R2 D' R U2 R' D R U2 R",
+		"r U R' U' r' F R F'",
+		"F' r U R' U' r' F R",
+		"R U2 R' U' R U' R'",
+		"R U R' U R U2 R'",
+		"r U R' U' M U R U' R'",
+		"R U R' U' R U' R' F' U' F R U R'",
+		"F R' F R2 U' R' U' R U R' F2",
+		"R' U' F U R U' R' F' R",
+		"L U F' U' L' U L F L'",
+		"R U R' U' R' F R F'",
+		"R U R2 U' R' F R U R U' F'",
+		"R U2 R2 F R F' R U2 R'",
+		"L' U' L U' L' U L U L F' L' F",
+		"F R' F' R U R U' R'",
+		"R U R' U R U' R' U' R' F R F'",
+		"L F' L' U' L U F U' L'",
+		"R' F R U R' U' F' U R",
+		"R U R' U R U2 R' F R U R' U' F'",
+		"R' U' R U' R' U2 R F R U R' U' F'",
+		"F' U' L' U L F",
+		"F U R U' R' F'",
+		"F R U R' U' F'",
+		"R' U' R' F R F' U R",
+		"R' U' R' F R F' R' F R F' U R",
+		"F R U R' U' R U R' U' F'",
+		"r U' r2 U r2 U r2 U' r",
+		"r' U r2 U' r2 U' r2 U r'",
+		"F U R U' R' U R U' R' F'",
+		"R U R' U R U' B U' B' R'",
+		"r' U' R U' R' U R U' R' U2 r",
+		"r U R' U R U' R' U R U2 r'",
+		"R' F R U R U' R2 F' R2 U' R' U R U R'",
+		"r U r' U R U' R' U R U' R' r U' r'",
+		"R U R' U' M' U R U' r'",
+	}
+	z := make([]lastLayerAlgorithm, len(algorithms))
+	for i, a := range algorithms {
+		z[i] = lastLayerAlgorithm{fmt.Sprintf("OLL %d", i+1), mustParseBuiltinAlgorithm(a)}
+	}
+	return z
+}()
+
+// pllAlgorithms permute the last layer once it is oriented, one for each of
+// the 21 PLL cases.
+var pllAlgorithms = []lastLayerAlgorithm{
+	{"Aa-perm", mustParseBuiltinAlgorithm("x R' U R' D2 R U' R' D2 R2 x'")},
+	{"Ab-perm", mustParseBuiltinAlgorithm("x R2 D2 R U R' D2 R U' R x'")},
+	{"E-perm", mustParseBuiltinAlgorithm("x' R U' R' D R U R' D' R U R' D R U' R' D' x")},
+	{"F-perm", mustParseBuiltinAlgorithm("R' U' F' R U R' U' R' F R2 U' R' U' R U R' U R")},
+	{"Ga-perm", mustParseBuiltinAlgorithm("R2 U R' U R' U' R U' R2 U' D R' U R D'")},
+	{"Gb-perm", mustParseBuiltinAlgorithm("R' U' R U D' R2 U R' U R U' R U' R2 D")},
+	{"Gc-perm", mustParseBuiltinAlgorithm("R2 U' R U' R U R' U R2 U D' R U' R' D")},
+	{"Gd-perm", mustParseBuiltinAlgorithm("R U R' U' D R2 U' R U' R' U R' U R2 D'")},
+	{"H-perm", mustParseBuiltinAlgorithm("M2 U M2 U2 M2 U M2")},
+	{"Ja-perm", mustParseBuiltinAlgorithm("R' U L' U2 R U' R' U2 R L U'")},
+	{"Jb-perm", mustParseBuiltinAlgorithm("R U R' F' R U R' U' R' F R2 U' R' U'")},
+	{"Na-perm", mustParseBuiltinAlgorithm("R U R' U R U R' F' R U R' U' R' F R2 U' R' U2 R U' R'")},
+	{"Nb-perm", mustParseBuiltinAlgorithm("R' U R U' R' F' U' F R U R' F R' F' R U' R")},
+	{"Ra-perm", mustParseBuiltinAlgorithm("R U' R' U' R U R D R' U' R D' R' U2 R' U'")},
+	{"Rb-perm", mustParseBuiltinAlgorithm("R2 F R U R U' R' F' R U2 R' U2 R")},
+	{"T-perm", mustParseBuiltinAlgorithm("R U R' U' R' F R2 U' R' U' R U R' F'")},
+	{"Ua-perm", mustParseBuiltinAlgorithm("M2 U M U2 M' U M2")},
+	{"Ub-perm", mustParseBuiltinAlgorithm("M2 U' M U2 M' U' M2")},
+	{"V-perm", mustParseBuiltinAlgorithm("R' U R' U' R D' R' D R' U D' R2 U' R2 D R2")},
+	{"Y-perm", mustParseBuiltinAlgorithm("F R U' R' U' R U R' F' R U R' U' R' F R F'")},
+	{"Z-perm", mustParseBuiltinAlgorithm("M' U M2 U M2 U M' U2 M2")},
+}
+
+// mustParseBuiltinAlgorithm parses an algorithm of the built in set, which are
+// checked by the tests so never fail to parse.
+func mustParseBuiltinAlgorithm(s string) Algorithm {
+	a, err := ParseAlgorithm(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
@@ -0,0 +1,130 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// lastLayerCases returns the state of the cube before the algorithm with
+// every turn of U before and after it, checking the first two layers are kept.
+func lastLayerCases(t *testing.T, l lastLayerAlgorithm, key func(RubiksCube) string) []string {
+	var z []string
+	for _, after := range upTurns {
+		for _, before := range upTurns {
+			cube := NewSolvedCube().Apply(after.Inverse().Concat(l.algorithm.Inverse(), before.Inverse()))
+			assert.Equal(t, FaceData{Yellow, Yellow, Yellow, Yellow, Yellow, Yellow, Yellow, Yellow, Yellow}, cube.Face(FaceDown), l.name)
+			for _, f := range beginnerSides {
+				face := cube.Face(f)
+				for j := 3; j < 9; j++ {
+					assert.Equal(t, face[4], face[j], l.name)
+				}
+			}
+			z = append(z, key(cube))
+		}
+	}
+	return z
+}
+
+// assertDistinctCases checks no two algorithms solve the same case.
+func assertDistinctCases(t *testing.T, algorithms []lastLayerAlgorithm, key func(RubiksCube) string) {
+	seen := make(map[string]string)
+	for _, l := range algorithms {
+		cases := lastLayerCases(t, l, key)
+		for _, s := range cases {
+			if other, found := seen[s]; found && other != l.name {
+				t.Errorf("%s and %s solve the same case", other, l.name)
+			}
+		}
+		for _, s := range cases {
+			seen[s] = l.name
+		}
+	}
+}
+
+func TestOLLAlgorithms(t *testing.T) {
+	assert.Len(t, ollAlgorithms, 57)
+	for _, l := range ollAlgorithms {
+		cube := NewSolvedCube().Apply(l.algorithm.Inverse())
+		assert.False(t, lastLayerOriented(cube), l.name)
+		assert.True(t, lastLayerOriented(cube.Apply(l.algorithm)), l.name)
+	}
+
+	// an OLL case only depends on which stickers of the last layer are white
+	assertDistinctCases(t, ollAlgorithms, func(cube RubiksCube) string {
+		var b []byte
+		for f := FaceUp; f <= FaceLeft; f++ {
+			for _, c := range cube.Face(f) {
+				if c == White {
+					b = append(b, '1')
+				} else {
+					b = append(b, '0')
+				}
+			}
+		}
+		return string(b)
+	})
+}
+
+func TestPLLAlgorithms(t *testing.T) {
+	assert.Len(t, pllAlgorithms, 21)
+	for _, l := range pllAlgorithms {
+		cube := NewSolvedCube().Apply(l.algorithm.Inverse())
+		assert.True(t, lastLayerOriented(cube), l.name)
+		assert.False(t, cube.IsSolved(), l.name)
+	}
+	assertDistinctCases(t, pllAlgorithms, RubiksCube.String)
+}
+
+func TestRecognizeOLL(t *testing.T) {
+	for _, l := range ollAlgorithms {
+		for _, auf := range upTurns {
+			cube := NewSolvedCube().Apply(l.algorithm.Inverse().Concat(auf))
+			c, err := RecognizeOLL(cube)
+			assert.NoError(t, err)
+			assert.Equal(t, l.name, c.Name)
+			assert.Empty(t, c.PostAUF)
+			assert.True(t, lastLayerOriented(cube.Apply(c.Solution())), l.name)
+
+			// the way the cube is held around the U layer does not matter
+			c, err = RecognizeOLL(cube.Apply(Algorithm{RotationY}))
+			assert.NoError(t, err)
+			assert.Equal(t, l.name, c.Name)
+		}
+	}
+
+	c, err := RecognizeOLL(NewSolvedCube().Apply(Algorithm{Up}))
+	assert.NoError(t, err)
+	assert.Equal(t, LastLayerCase{PreAUF: Algorithm{}, Algorithm: Algorithm{}, PostAUF: Algorithm{}}, c)
+
+	_, err = RecognizeOLL(NewSolvedCube().Apply(Algorithm{Right}))
+	assert.ErrorIs(t, err, ErrF2LUnsolved)
+	assert.False(t, NewSolvedCube().Apply(Algorithm{Right}).IsF2LSolved())
+}
+
+func TestRecognizePLL(t *testing.T) {
+	for _, l := range pllAlgorithms {
+		for _, after := range upTurns {
+			for _, before := range upTurns {
+				cube := NewSolvedCube().Apply(after.Inverse().Concat(l.algorithm.Inverse(), before.Inverse()))
+				c, err := RecognizePLL(cube)
+				assert.NoError(t, err)
+				assert.Equal(t, l.name, c.Name)
+				assert.True(t, cube.Apply(c.Solution()).IsSolved(), l.name)
+			}
+		}
+	}
+
+	c, err := RecognizePLL(NewSolvedCube().Apply(Algorithm{UpPrime}))
+	assert.NoError(t, err)
+	assert.Equal(t, LastLayerCase{PreAUF: Algorithm{Up}, Algorithm: Algorithm{}, PostAUF: Algorithm{}}, c)
+
+	c, err = RecognizePLL(NewSolvedCube().Apply(mustParseAlgorithm(t, "U R U R' U' R' F R2 U' R' U' R U R' F'")))
+	assert.NoError(t, err)
+	assert.Equal(t, "T-perm", c.Name)
+	assert.Equal(t, Algorithm{UpPrime}, c.PostAUF)
+
+	_, err = RecognizePLL(NewSolvedCube().Apply(ollAlgorithms[26].algorithm))
+	assert.ErrorIs(t, err, ErrLastLayerUnoriented)
+	_, err = RecognizePLL(NewSolvedCube().Apply(Algorithm{Front}))
+	assert.ErrorIs(t, err, ErrF2LUnsolved)
+}
@@ -1,9 +1,7 @@
 package rubiks_cube
 
 import (
-	"bufio"
 	"errors"
-	"fmt"
 	"io"
 )
 
@@ -26,74 +24,233 @@ const (
 	BackPrime
 	RightPrime
 	LeftPrime
+	Up2
+	Down2
+	Front2
+	Back2
+	Right2
+	Left2
+	Middle
+	Equator
+	Standing
+	MiddlePrime
+	EquatorPrime
+	StandingPrime
+	Middle2
+	Equator2
+	Standing2
+	RotationX
+	RotationY
+	RotationZ
+	RotationXPrime
+	RotationYPrime
+	RotationZPrime
+	RotationX2
+	RotationY2
+	RotationZ2
+	UpWide
+	DownWide
+	FrontWide
+	BackWide
+	RightWide
+	LeftWide
+	UpWidePrime
+	DownWidePrime
+	FrontWidePrime
+	BackWidePrime
+	RightWidePrime
+	LeftWidePrime
+	UpWide2
+	DownWide2
+	FrontWide2
+	BackWide2
+	RightWide2
+	LeftWide2
 )
 
+// wideRotationTable is the whole cube rotation made by turning all three
+// layers in the same direction as each wide move.
+var wideRotationTable = [6]Move{
+	RotationY,
+	RotationYPrime,
+	RotationZ,
+	RotationZPrime,
+	RotationX,
+	RotationXPrime,
+}
+
+// moveGroups lists the first move and number of faces in each group of moves.
+// Each group has the clockwise moves followed by the prime moves and then the
+// double moves.
+var moveGroups = []struct {
+	first Move
+	size  Move
+}{
+	{Up, 6},
+	{Middle, 3},
+	{RotationX, 3},
+	{UpWide, 6},
+}
+
+// split returns the first move of the group containing m, the size of the
+// group, the index of the face and the amount of turning where 0 is clockwise,
+// 1 is prime and 2 is double.
+func (m Move) split() (first, size, face, amount Move) {
+	for _, g := range moveGroups {
+		if m >= g.first && m < g.first+3*g.size {
+			i := m - g.first
+			return g.first, g.size, i % g.size, i / g.size
+		}
+	}
+	return m, 0, 0, 0
+}
+
+// Reverse returns the move which undoes m. Double moves are their own reverse.
 func (m Move) Reverse() Move {
-	return (m + 6) % 6
+	first, size, face, amount := m.split()
+	switch amount {
+	case 0:
+		return first + size + face
+	case 1:
+		return first + face
+	}
+	return m
 }
 
 func (m Move) Prime() bool {
-	return m >= UpPrime
+	_, size, _, amount := m.split()
+	return size != 0 && amount == 1
 }
 
+// Double returns true for the half turn moves.
+func (m Move) Double() bool {
+	_, size, _, amount := m.split()
+	return size != 0 && amount == 2
+}
+
+// Slice returns true for the moves turning one of the middle slices.
+func (m Move) Slice() bool {
+	return m >= Middle && m <= Standing2
+}
+
+// Rotation returns true for the moves turning the whole cube.
+func (m Move) Rotation() bool {
+	return m >= RotationX && m <= RotationZ2
+}
+
+// Wide returns true for the moves turning a face and the slice next to it.
+func (m Move) Wide() bool {
+	return m >= UpWide && m <= LeftWide2
+}
+
+// Quarter returns the clockwise quarter turn of the same layers as m.
+func (m Move) Quarter() Move {
+	first, _, face, _ := m.split()
+	return first + face
+}
+
+// Twice returns the half turn of the same layers as m.
+func (m Move) Twice() Move {
+	first, size, face, _ := m.split()
+	return first + 2*size + face
+}
+
+// Axis returns the axis which the layers turned by m rotate around.
+func (m Move) Axis() TurnOfCubelet {
+	_, size, face, _ := m.split()
+	switch size {
+	case 0:
+		return 255
+	case 3:
+		return sliceAxisTable[face]
+	}
+	return TurnOfCubelet(face / 2)
+}
+
+// sliceAxisTable is the axis of the slices and rotations in the order of the
+// M, E and S slices.
+var sliceAxisTable = [3]TurnOfCubelet{TurnOfRightLeft, TurnOfUpDown, TurnOfFrontBack}
+
+// mirror returns the move m reflected across the plane between the two faces
+// on the axis. The faces on that axis are swapped and the direction of every
+// turn is reversed, except for the slices and rotations around the axis which
+// are unchanged.
+func (m Move) mirror(axis TurnOfCubelet) Move {
+	first, size, face, amount := m.split()
+	if size == 0 || m.Axis() == axis && size == 3 {
+		return m
+	}
+	if m.Axis() == axis {
+		face ^= 1
+	}
+	switch amount {
+	case 0:
+		amount = 1
+	case 1:
+		amount = 0
+	}
+	return first + amount*size + face
+}
+
+// Notation returns the move written in WCA notation.
+func (m Move) Notation() string {
+	first, _, face, amount := m.split()
+	var s string
+	switch first {
+	case Up:
+		s = faceNotationTable[face]
+	case Middle:
+		s = sliceNotationTable[face]
+	case RotationX:
+		s = rotationNotationTable[face]
+	case UpWide:
+		s = faceNotationTable[face] + "w"
+	default:
+		return m.String()
+	}
+	return s + amountNotationTable[amount]
+}
+
+var (
+	faceNotationTable     = [6]string{"U", "D", "F", "B", "R", "L"}
+	sliceNotationTable    = [3]string{"M", "E", "S"}
+	rotationNotationTable = [3]string{"x", "y", "z"}
+	amountNotationTable   = [3]string{"", "'", "2"}
+)
+
 func (m Move) Valid() bool {
-	return m <= LeftPrime
+	return m <= LeftWide2
 }
 
+// MoveScanner reads the moves of an algorithm one at a time. The whole
+// algorithm is read and parsed by ParseAlgorithm on the first call to Scan.
 type MoveScanner struct {
-	b           *bufio.Reader
+	r           io.Reader
+	moves       Algorithm
+	started     bool
 	err         error
 	currentMove Move
 }
 
 func NewMoveScanner(r io.Reader) *MoveScanner {
-	return &MoveScanner{b: bufio.NewReader(r)}
+	return &MoveScanner{r: r}
 }
 
 func (s *MoveScanner) Scan() bool {
-	readByte, err := s.b.ReadByte()
-	if err != nil {
-		if errors.Is(err, io.EOF) {
+	if !s.started {
+		s.started = true
+		b, err := io.ReadAll(s.r)
+		if err != nil {
+			s.err = err
 			return false
 		}
-		s.err = err
-		return false
+		s.moves, s.err = ParseAlgorithm(string(b))
 	}
-	switch readByte {
-	case 'U':
-		s.currentMove = Up
-	case 'D':
-		s.currentMove = Down
-	case 'F':
-		s.currentMove = Front
-	case 'B':
-		s.currentMove = Back
-	case 'R':
-		s.currentMove = Right
-	case 'L':
-		s.currentMove = Left
-	default:
-		s.err = ErrInvalidMove
+	if s.err != nil || len(s.moves) == 0 {
 		return false
 	}
-	readByte, err = s.b.ReadByte()
-	if err != nil {
-		if errors.Is(err, io.EOF) {
-			return false
-		}
-		s.err = err
-		return false
-	}
-	fmt.Println(s.currentMove, rune(readByte))
-	if readByte == '\'' {
-		s.currentMove = s.currentMove.Reverse()
-	} else {
-		s.err = s.b.UnreadByte()
-		if s.err != nil {
-			return false
-		}
-	}
-	fmt.Println(s.currentMove)
+	s.currentMove = s.moves[0]
+	s.moves = s.moves[1:]
 	return true
 }
 
@@ -101,6 +258,8 @@ func (s *MoveScanner) Current() Move {
 	return s.currentMove
 }
 
+// Err returns the first error found while reading the algorithm, this will be
+// a *ParseError if the algorithm is invalid.
 func (s *MoveScanner) Err() error {
 	return s.err
 }
@@ -20,11 +20,53 @@ func _() {
 	_ = x[BackPrime-9]
 	_ = x[RightPrime-10]
 	_ = x[LeftPrime-11]
+	_ = x[Up2-12]
+	_ = x[Down2-13]
+	_ = x[Front2-14]
+	_ = x[Back2-15]
+	_ = x[Right2-16]
+	_ = x[Left2-17]
+	_ = x[Middle-18]
+	_ = x[Equator-19]
+	_ = x[Standing-20]
+	_ = x[MiddlePrime-21]
+	_ = x[EquatorPrime-22]
+	_ = x[StandingPrime-23]
+	_ = x[Middle2-24]
+	_ = x[Equator2-25]
+	_ = x[Standing2-26]
+	_ = x[RotationX-27]
+	_ = x[RotationY-28]
+	_ = x[RotationZ-29]
+	_ = x[RotationXPrime-30]
+	_ = x[RotationYPrime-31]
+	_ = x[RotationZPrime-32]
+	_ = x[RotationX2-33]
+	_ = x[RotationY2-34]
+	_ = x[RotationZ2-35]
+	_ = x[UpWide-36]
+	_ = x[DownWide-37]
+	_ = x[FrontWide-38]
+	_ = x[BackWide-39]
+	_ = x[RightWide-40]
+	_ = x[LeftWide-41]
+	_ = x[UpWidePrime-42]
+	_ = x[DownWidePrime-43]
+	_ = x[FrontWidePrime-44]
+	_ = x[BackWidePrime-45]
+	_ = x[RightWidePrime-46]
+	_ = x[LeftWidePrime-47]
+	_ = x[UpWide2-48]
+	_ = x[DownWide2-49]
+	_ = x[FrontWide2-50]
+	_ = x[BackWide2-51]
+	_ = x[RightWide2-52]
+	_ = x[LeftWide2-53]
 }
 
-const _Move_name = "UpDownFrontBackRightLeftUpPrimeDownPrimeFrontPrimeBackPrimeRightPrimeLeftPrime"
+const _Move_name = "UpDownFrontBackRightLeftUpPrimeDownPrimeFrontPrimeBackPrimeRightPrimeLeftPrimeUp2Down2Front2Back2Right2Left2MiddleEquatorStandingMiddlePrimeEquatorPrimeStandingPrimeMiddle2Equator2Standing2RotationXRotationYRotationZRotationXPrimeRotationYPrimeRotationZPrimeRotationX2RotationY2RotationZ2UpWideDownWideFrontWideBackWideRightWideLeftWideUpWidePrimeDownWidePrimeFrontWidePrimeBackWidePrimeRightWidePrimeLeftWidePrimeUpWide2DownWide2FrontWide2BackWide2RightWide2LeftWide2"
 
-var _Move_index = [...]uint8{0, 2, 6, 11, 15, 20, 24, 31, 40, 50, 59, 69, 78}
+var _Move_index = [...]uint16{0, 2, 6, 11, 15, 20, 24, 31, 40, 50, 59, 69, 78, 81, 86, 92, 97, 103, 108, 114, 121, 129, 140, 152, 165, 172, 180, 189, 198, 207, 216, 230, 244, 258, 268, 278, 288, 294, 302, 311, 319, 328, 336, 347, 360, 374, 387, 401, 414, 421, 430, 440, 449, 459, 468}
 
 func (i Move) String() string {
 	if i >= Move(len(_Move_index)-1) {
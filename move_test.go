@@ -1,18 +1,40 @@
 package rubiks_cube
 
 import (
+	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
 )
 
 func TestMoveScanner(t *testing.T) {
-	m := []Move{Up, Right, LeftPrime, Down, Left, RightPrime, Front, Up, Back}
-	s := NewMoveScanner(strings.NewReader("URL'DLR'FUB"))
-	i := 0
+	m := []Move{Up, Right, LeftPrime, Down, Left, RightPrime, Front, Up2, Back, Right2, Down2, FrontPrime, Middle, EquatorPrime, Standing2, RotationX, RotationYPrime, RotationZ2, RightWide, UpWidePrime, FrontWide2, LeftWide, BackWidePrime, DownWide2, RightWide, RotationX, RotationYPrime}
+	s := NewMoveScanner(strings.NewReader("URL'DLR'FU2BR2D2'F'ME'S2xy'z2ru'f2Lwb'Dw2' 2Rw 3Rw 3Dw"))
+	var a []Move
 	for s.Scan() {
-		if s.Current() == m[i] {
+		a = append(a, s.Current())
+	}
+	assert.NoError(t, s.Err())
+	assert.Equal(t, m, a)
+}
+
+func TestMove_Reverse(t *testing.T) {
+	assert.Equal(t, UpPrime, Up.Reverse())
+	assert.Equal(t, Left, LeftPrime.Reverse())
+	assert.Equal(t, Front2, Front2.Reverse())
+	assert.Equal(t, MiddlePrime, Middle.Reverse())
+	assert.Equal(t, Standing, StandingPrime.Reverse())
+	assert.Equal(t, RotationYPrime, RotationY.Reverse())
+	assert.Equal(t, LeftWide2, LeftWide2.Reverse())
+	for i := Up; i <= LeftWide2; i++ {
+		assert.Equal(t, i, i.Reverse().Reverse())
+	}
+}
 
+func TestMoveScanner_Invalid(t *testing.T) {
+	for _, i := range []string{"Q", "2R", "3x", "R''"} {
+		s := NewMoveScanner(strings.NewReader(i))
+		for s.Scan() {
 		}
-		i++
+		assert.ErrorIs(t, s.Err(), ErrInvalidMove, i)
 	}
 }
@@ -0,0 +1,253 @@
+package rubiks_cube
+
+import (
+	"context"
+)
+
+// Metric is the way moves are counted when searching for the shortest
+// algorithm.
+type Metric byte
+
+const (
+	// HalfTurnMetric counts every face move as one move, see Algorithm.HTM.
+	HalfTurnMetric Metric = iota
+
+	// QuarterTurnMetric counts half turns as two moves, see Algorithm.QTM.
+	QuarterTurnMetric
+)
+
+// length returns the length of the algorithm in the metric.
+func (m Metric) length(a Algorithm) int {
+	if m == QuarterTurnMetric {
+		return a.QTM()
+	}
+	return a.HTM()
+}
+
+// OptimalOptions changes how SolveOptimal searches for a solution.
+type OptimalOptions struct {
+	// Metric is the way moves are counted. The zero value is HalfTurnMetric.
+	Metric Metric
+
+	// Tables are the tables used by the search. Nil uses DefaultTables.
+	Tables *Tables
+
+	// Patterns are the pattern databases used by the search. Nil uses
+	// DefaultPatternDatabases.
+	Patterns *PatternDatabases
+
+	// MaxDepth is the longest solution the search looks for, counted in
+	// Metric. If every solution this short is ruled out then the solution
+	// from Solve is returned with Optimal set to false. Zero searches every
+	// length.
+	MaxDepth int
+}
+
+// OptimalSolution is the result of SolveOptimal.
+type OptimalSolution struct {
+	// Algorithm solves the cube.
+	Algorithm Algorithm
+
+	// Optimal is true if the search proved that no shorter algorithm solves
+	// the cube.
+	Optimal bool
+
+	// LowerBound is the length below which every algorithm was ruled out. It
+	// is the length of Algorithm when Optimal is true.
+	LowerBound int
+}
+
+// SolveOptimal finds the shortest algorithm which solves the cube with an
+// iterative deepening A* search, as used by Korf. The length of each partial
+// solution plus a lower bound from the pattern databases and the pruning
+// tables gives the shortest possible solution through it, and each search
+// only continues while this is within the current depth.
+//
+// Each table is looked up with the cube turned onto each of its three axes,
+// which uses the symmetry of the cube to make one table of the FR, FL, BL and
+// BR edges cover the edges of every slice.
+//
+// A solution from Solve is found first, so if ctx is done before the search
+// finishes this solution is returned with Optimal set to false along with the
+// error of ctx. Finding the optimal solution of a well scrambled cube can take
+// a very long time.
+//
+// The algorithm only uses face moves and turns the faces as the cube is held.
+// The cube is checked with RubiksCube.Validate first.
+func SolveOptimal(ctx context.Context, cube RubiksCube, opts OptimalOptions) (OptimalSolution, error) {
+	if err := cube.Validate(); err != nil {
+		return OptimalSolution{}, err
+	}
+	s := &optimalSearch{
+		ctx:      ctx,
+		tables:   opts.Tables,
+		patterns: opts.Patterns,
+		metric:   opts.Metric,
+	}
+	if s.tables == nil {
+		s.tables = DefaultTables()
+	}
+	if s.patterns == nil {
+		s.patterns = DefaultPatternDatabases()
+	}
+	s.moves = Algorithm{Up, Down, Front, Back, Right, Left, UpPrime, DownPrime, FrontPrime, BackPrime, RightPrime, LeftPrime}
+	if s.metric != QuarterTurnMetric {
+		s.moves = append(s.moves, Up2, Down2, Front2, Back2, Right2, Left2)
+	}
+	s.axisMoves = optimalAxisMoves()
+
+	standard := cube.Hold(StandardOrientation)
+	upper, err := Solve(standard, SolveOptions{Tables: s.tables})
+	if err != nil {
+		return OptimalSolution{}, err
+	}
+
+	c := standard.CubieCube()
+	start := optimalNode{corners: uint16(c.CornerPermutation())}
+	for a, o := range axisOrientations() {
+		rot := orientationCubies[o]
+		v := rot.Inverse().Multiply(c).Multiply(rot)
+		start.twist[a], start.flip[a], start.slice[a] = uint16(v.Twist()), uint16(v.Flip()), uint16(v.SliceSorted())
+	}
+
+	// every quarter turn changes the parity of the corners, so in the
+	// quarter turn metric only every other depth needs to be searched
+	step := 1
+	depth := s.distance(start)
+	if s.metric == QuarterTurnMetric {
+		step = 2
+		if cube.Parity() != (depth%2 == 1) {
+			depth++
+		}
+	}
+	for ; depth < s.metric.length(upper); depth += step {
+		if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+			return OptimalSolution{Algorithm: holdAlgorithm(cube.Orientation, upper), LowerBound: depth}, nil
+		}
+		if s.search(start, 0, depth) {
+			solution := Algorithm(s.path[:depth]).Simplify()
+			return OptimalSolution{Algorithm: holdAlgorithm(cube.Orientation, solution), Optimal: true, LowerBound: depth}, nil
+		}
+		if s.err != nil {
+			return OptimalSolution{Algorithm: holdAlgorithm(cube.Orientation, upper), LowerBound: depth}, s.err
+		}
+	}
+	return OptimalSolution{Algorithm: holdAlgorithm(cube.Orientation, upper), Optimal: true, LowerBound: s.metric.length(upper)}, nil
+}
+
+// optimalAxisMoves finds the move of the cube turned onto each axis which
+// matches each face move of the cube.
+func optimalAxisMoves() [axisCount][faceMoveCount]Move {
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	var z [axisCount][faceMoveCount]Move
+	for a, o := range axisOrientations() {
+		rot := orientationCubies[o]
+		for m := range moves {
+			turned := rot.Inverse().Multiply(moves[m]).Multiply(rot)
+			for n := range moves {
+				if moves[n] == turned {
+					z[a][m] = Move(n)
+				}
+			}
+		}
+	}
+	return z
+}
+
+// optimalNode holds the coordinates of the cube during the search. The twist,
+// flip and slice are kept for the cube turned onto each axis.
+type optimalNode struct {
+	corners            uint16
+	twist, flip, slice [axisCount]uint16
+}
+
+// optimalSearch holds the state of a search by SolveOptimal. The path is long
+// enough for the quarter turn length of any solution from Solve.
+type optimalSearch struct {
+	ctx       context.Context
+	tables    *Tables
+	patterns  *PatternDatabases
+	metric    Metric
+	moves     Algorithm
+	axisMoves [axisCount][faceMoveCount]Move
+	nodes     int
+	err       error
+	path      [2 * DefaultMaxLength]Move
+}
+
+// stop returns true once ctx is done. The context is only checked every few
+// thousand nodes.
+func (s *optimalSearch) stop() bool {
+	s.nodes++
+	if s.err == nil && s.nodes%4096 == 0 {
+		s.err = s.ctx.Err()
+	}
+	return s.err != nil
+}
+
+// distance returns a lower bound for the number of moves needed to solve the
+// cube, which is only zero for a solved cube.
+func (s *optimalSearch) distance(n optimalNode) int {
+	t, p := s.tables, s.patterns
+	d := int(p.Corners.Get(int(n.corners)*TwistCount + int(n.twist[0])))
+	for a := 0; a < axisCount; a++ {
+		d = max(d,
+			int(p.Edges.Get(int(n.flip[a])*SliceSortedCount+int(n.slice[a]))),
+			int(t.FlipSliceTwistPrune.Get(t.flipSlice.index(n.twist[a], n.flip[a], int(n.slice[a])/sliceEdgePermutationSize))),
+		)
+	}
+	return d
+}
+
+// search returns true if there is a sequence of exactly togo moves which
+// solves the cube.
+func (s *optimalSearch) search(node optimalNode, n, togo int) bool {
+	if s.stop() {
+		return false
+	}
+	if togo == 0 {
+		return s.distance(node) == 0
+	}
+	t := s.tables
+	for _, m := range s.moves {
+		if !s.canFollow(n, m) {
+			continue
+		}
+		next := optimalNode{corners: t.CornerPermutationMove[node.corners][m]}
+		for a := 0; a < axisCount; a++ {
+			am := s.axisMoves[a][m]
+			next.twist[a] = t.TwistMove[node.twist[a]][am]
+			next.flip[a] = t.FlipMove[node.flip[a]][am]
+			next.slice[a] = t.SliceSortedMove[node.slice[a]][am]
+		}
+		if s.distance(next) >= togo {
+			continue
+		}
+		s.path[n] = m
+		if s.search(next, n+1, togo-1) {
+			return true
+		}
+		if s.err != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// canFollow returns false if the move m after the first n moves of the path
+// makes a sequence which can be written with fewer moves or is found in
+// another order. In the quarter turn metric a half turn is made by turning a
+// face clockwise twice.
+func (s *optimalSearch) canFollow(n int, m Move) bool {
+	if n == 0 {
+		return true
+	}
+	last := s.path[n-1]
+	if s.metric != QuarterTurnMetric || m%6 != last%6 {
+		return canFollow(last, m)
+	}
+	return m == last && !m.Prime() && (n < 2 || s.path[n-2]%6 != m%6)
+}
@@ -0,0 +1,123 @@
+package rubiks_cube
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveOptimal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the pattern databases take several seconds to generate")
+	}
+	ctx := context.Background()
+	solution, err := SolveOptimal(ctx, NewSolvedCube(), OptimalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, OptimalSolution{Algorithm: Algorithm{}, Optimal: true}, solution)
+
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U R' U'"))
+	solution, err = SolveOptimal(ctx, cube, OptimalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "U R U' R'", solution.Algorithm.String())
+	assert.True(t, solution.Optimal)
+	assert.Equal(t, 4, solution.LowerBound)
+
+	// the solution turns the faces as the cube is held
+	cube = NewSolvedCube().Apply(mustParseAlgorithm(t, "y R U R' U'"))
+	solution, err = SolveOptimal(ctx, cube, OptimalOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "U R U' R'", solution.Algorithm.String())
+
+	cube = NewSolvedCube().Apply(mustParseAlgorithm(t, "R2 U"))
+	solution, err = SolveOptimal(ctx, cube, OptimalOptions{Metric: QuarterTurnMetric})
+	assert.NoError(t, err)
+	assert.Equal(t, "U' R2", solution.Algorithm.String())
+	assert.Equal(t, 3, solution.LowerBound)
+
+	// compare with every short algorithm
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 20; i++ {
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 4))
+		shortest := shortestSolution(cube, all, 4)
+		for _, metric := range []Metric{HalfTurnMetric, QuarterTurnMetric} {
+			solution, err := SolveOptimal(ctx, cube, OptimalOptions{Metric: metric})
+			assert.NoError(t, err)
+			assert.True(t, solution.Optimal)
+			assert.True(t, cube.Apply(solution.Algorithm).IsSolved(), solution.Algorithm.String())
+			if metric == HalfTurnMetric {
+				assert.Equal(t, len(shortest), solution.Algorithm.HTM())
+			} else {
+				assert.LessOrEqual(t, solution.Algorithm.QTM(), shortest.QTM())
+				assert.GreaterOrEqual(t, solution.Algorithm.QTM(), len(shortest))
+			}
+			assert.Equal(t, metric.length(solution.Algorithm), solution.LowerBound)
+		}
+	}
+
+	cube, _ = NewCubeBuilder(NewSolvedCube()).TwistCorner(SlotUFR, 1).AllowIllegal().Build()
+	_, err = SolveOptimal(ctx, cube, OptimalOptions{})
+	assert.ErrorIs(t, err, ErrTwistedCorner)
+}
+
+func TestSolveOptimal_Cancel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the pattern databases take several seconds to generate")
+	}
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "D2 F' U2 B2 R2 F' L2 B' R2 U2 F D' L' B R' U' F2 L2 D' R' F'"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	solution, err := SolveOptimal(ctx, cube, OptimalOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, solution.Optimal)
+	assert.True(t, cube.Apply(solution.Algorithm).IsSolved())
+	assert.LessOrEqual(t, len(solution.Algorithm), DefaultMaxLength)
+	assert.Greater(t, solution.LowerBound, 0)
+}
+
+func TestSolveOptimal_MaxDepth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the pattern databases take several seconds to generate")
+	}
+	ctx := context.Background()
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U R' U'"))
+	solution, err := SolveOptimal(ctx, cube, OptimalOptions{MaxDepth: 3})
+	assert.NoError(t, err)
+	assert.False(t, solution.Optimal)
+	assert.True(t, cube.Apply(solution.Algorithm).IsSolved())
+	assert.Equal(t, 4, solution.LowerBound)
+
+	solution, err = SolveOptimal(ctx, cube, OptimalOptions{MaxDepth: 4})
+	assert.NoError(t, err)
+	assert.True(t, solution.Optimal)
+	assert.Equal(t, "U R U' R'", solution.Algorithm.String())
+}
+
+// shortestSolution tries every algorithm of the moves up to n moves long.
+func shortestSolution(cube RubiksCube, moves Algorithm, n int) Algorithm {
+	var try func(c RubiksCube, a Algorithm, n int) Algorithm
+	try = func(c RubiksCube, a Algorithm, n int) Algorithm {
+		if c.IsSolved() {
+			return a
+		}
+		if n == 0 {
+			return nil
+		}
+		for _, m := range moves {
+			if z := try(c.Move(m), append(a, m), n-1); z != nil {
+				return z
+			}
+		}
+		return nil
+	}
+	for i := 0; i <= n; i++ {
+		if z := try(cube, Algorithm{}, i); z != nil {
+			return z
+		}
+	}
+	return nil
+}
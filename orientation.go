@@ -0,0 +1,317 @@
+package rubiks_cube
+
+// Orientation stores which way a cube is being held. The cubelets of a
+// RubiksCube are always stored relative to the centers, so slice moves and
+// turning the whole cube only change the orientation of the cube.
+//
+// There are 24 orientations, one for every face which can be held up combined
+// with the four faces which can then be held at the front.
+type Orientation byte
+
+// StandardOrientation holds the cube with the white center up and the orange
+// center at the front, this is the zero value of Orientation.
+const StandardOrientation Orientation = 0
+
+func (o Orientation) Valid() bool {
+	return int(o) < len(orientationTable)
+}
+
+// Face returns the face of a cube held in the standard orientation which is
+// now being held at the face f.
+func (o Orientation) Face(f Face) Face {
+	return orientationTable[o][f]
+}
+
+// NewOrientation returns the orientation where the face up of the standard
+// orientation is held up and the face front is held at the front. The invalid
+// value 255 is returned if the faces are not next to each other.
+func NewOrientation(up, front Face) Orientation {
+	if !up.Valid() || !front.Valid() || front == up || front == up^1 {
+		return 255
+	}
+	return orientationLookup[up][front]
+}
+
+// Compose returns the orientation of the cube after it is held in orientation
+// o and then turned the same way as turning a cube from the standard
+// orientation to the orientation p.
+func (o Orientation) Compose(p Orientation) Orientation {
+	return orientationLookup[o.Face(p.Face(FaceUp))][o.Face(p.Face(FaceFront))]
+}
+
+// Inverse returns the orientation which turns a cube held in orientation o
+// back to the standard orientation.
+func (o Orientation) Inverse() Orientation {
+	var up, front Face
+	for f := FaceUp; f <= FaceLeft; f++ {
+		switch o.Face(f) {
+		case FaceUp:
+			up = f
+		case FaceFront:
+			front = f
+		}
+	}
+	return orientationLookup[up][front]
+}
+
+// Rotate returns the orientation after applying the whole cube rotation m.
+// Moves which are not rotations leave the orientation unchanged.
+func (o Orientation) Rotate(m Move) Orientation {
+	switch m {
+	case RotationX:
+		return o.Compose(orientationX)
+	case RotationY:
+		return o.Compose(orientationY)
+	case RotationZ:
+		return o.Compose(orientationZ)
+	case RotationXPrime:
+		return o.Compose(orientationXPrime)
+	case RotationYPrime:
+		return o.Compose(orientationYPrime)
+	case RotationZPrime:
+		return o.Compose(orientationZPrime)
+	case RotationX2, RotationY2, RotationZ2:
+		q := m.Quarter()
+		return o.Rotate(q).Rotate(q)
+	}
+	return o
+}
+
+// Rotations returns the shortest sequence of whole cube rotations which turns a
+// cube from the standard orientation to the orientation o.
+func (o Orientation) Rotations() Algorithm {
+	return append(Algorithm(nil), orientationRotations[o]...)
+}
+
+// rotationsToHold returns the fewest whole cube rotations which turn a cube
+// held in orientation o so the face f of the standard orientation is held at
+// the face at.
+func (o Orientation) rotationsToHold(f, at Face) Algorithm {
+	var best Algorithm
+	found := false
+	for p := Orientation(0); p.Valid(); p++ {
+		rotations := p.Rotations()
+		q := o
+		for _, m := range rotations {
+			q = q.Rotate(m)
+		}
+		if q.Face(at) == f && (!found || len(rotations) < len(best)) {
+			best, found = rotations, true
+		}
+	}
+	return best
+}
+
+// translate returns the move which turns the same layers in the same
+// direction as the move m does after first turning the cube to orientation o.
+func (o Orientation) translate(m Move) Move {
+	first, size, face, amount := m.split()
+	switch size {
+	case 0:
+		return m
+	case 6:
+		return first + amount*size + Move(o.Face(Face(face)))
+	}
+
+	// slices and rotations turn in the same direction as a reference face
+	ref := o.Face(sliceReferenceTable[first][face])
+	var n Move
+	for i, j := range sliceAxisTable {
+		if TurnOfCubelet(ref/2) == j {
+			n = Move(i)
+		}
+	}
+	if ref != sliceReferenceTable[first][n] && amount < 2 {
+		amount ^= 1
+	}
+	return first + amount*size + n
+}
+
+// untranslate is the reverse of translate, it returns the move which turns the
+// same layers on a cube held in orientation o as the move m does on a cube in
+// the standard orientation.
+func (o Orientation) untranslate(m Move) Move {
+	for n := Up; n <= LeftWide2; n++ {
+		if o.translate(n) == m {
+			return n
+		}
+	}
+	return m
+}
+
+// sliceReferenceTable is the face which turns in the same direction as each
+// slice and rotation.
+var sliceReferenceTable = map[Move][3]Face{
+	Middle:    {FaceLeft, FaceDown, FaceFront},
+	RotationX: {FaceRight, FaceUp, FaceFront},
+}
+
+var (
+	// orientationTable maps the faces of each orientation to the faces of the
+	// standard orientation.
+	orientationTable [24][6]Face
+
+	// orientationLookup finds an orientation from the faces held up and at the
+	// front.
+	orientationLookup [6][6]Orientation
+
+	// orientationFacelets maps the index of each facelet on a face of an
+	// orientation to the index of the same facelet on the face of the standard
+	// orientation.
+	orientationFacelets [24][6][9]byte
+
+	// orientationX, orientationY and orientationZ are the orientations after
+	// turning the whole cube like the right, up and front faces.
+	orientationX, orientationXPrime Orientation
+	orientationY, orientationYPrime Orientation
+	orientationZ, orientationZPrime Orientation
+
+	// orientationRotations is the shortest sequence of rotations from the
+	// standard orientation to each orientation.
+	orientationRotations [24]Algorithm
+
+	// orientationCubies moves the pieces the same way as turning the whole
+	// cube from the standard orientation to each orientation.
+	orientationCubies [24]CubieCube
+)
+
+// vector is a position or direction relative to the center of the cube. Each
+// cubelet is one unit wide.
+type vector [3]int
+
+var (
+	vectorUp    = vector{0, 1, 0}
+	vectorDown  = vector{0, -1, 0}
+	vectorFront = vector{0, 0, 1}
+	vectorBack  = vector{0, 0, -1}
+	vectorRight = vector{1, 0, 0}
+	vectorLeft  = vector{-1, 0, 0}
+)
+
+var faceNormals = [6]vector{
+	FaceUp:    vectorUp,
+	FaceDown:  vectorDown,
+	FaceFront: vectorFront,
+	FaceBack:  vectorBack,
+	FaceRight: vectorRight,
+	FaceLeft:  vectorLeft,
+}
+
+// faceColumns and faceRows are the directions along the columns and down the
+// rows of each face in the diagram on RubiksCube.Face.
+var (
+	faceColumns = [6]vector{
+		FaceUp:    vectorRight,
+		FaceDown:  vectorRight,
+		FaceFront: vectorRight,
+		FaceBack:  vectorLeft,
+		FaceRight: vectorBack,
+		FaceLeft:  vectorFront,
+	}
+	faceRows = [6]vector{
+		FaceUp:    vectorFront,
+		FaceDown:  vectorBack,
+		FaceFront: vectorDown,
+		FaceBack:  vectorDown,
+		FaceRight: vectorDown,
+		FaceLeft:  vectorDown,
+	}
+)
+
+func (v vector) add(u vector) vector {
+	return vector{v[0] + u[0], v[1] + u[1], v[2] + u[2]}
+}
+
+func (v vector) scale(n int) vector {
+	return vector{v[0] * n, v[1] * n, v[2] * n}
+}
+
+func (v vector) cross(u vector) vector {
+	return vector{v[1]*u[2] - v[2]*u[1], v[2]*u[0] - v[0]*u[2], v[0]*u[1] - v[1]*u[0]}
+}
+
+func faceOfNormal(v vector) Face {
+	for i, j := range faceNormals {
+		if j == v {
+			return Face(i)
+		}
+	}
+	panic("vector is not a face normal")
+}
+
+// faceletVector returns the position of the facelet i on the face f.
+func faceletVector(f Face, i int) vector {
+	return faceNormals[f].add(faceColumns[f].scale(i%3 - 1)).add(faceRows[f].scale(i/3 - 1))
+}
+
+// toStandard converts a vector in the frame of the orientation o into the
+// frame of the standard orientation.
+func (o Orientation) toStandard(v vector) vector {
+	return faceNormals[o.Face(FaceRight)].scale(v[0]).
+		add(faceNormals[o.Face(FaceUp)].scale(v[1])).
+		add(faceNormals[o.Face(FaceFront)].scale(v[2]))
+}
+
+func init() {
+	n := 0
+	for up := FaceUp; up <= FaceLeft; up++ {
+		for front := FaceUp; front <= FaceLeft; front++ {
+			if front == up || front == up^1 {
+				continue
+			}
+			right := faceOfNormal(faceNormals[up].cross(faceNormals[front]))
+			orientationTable[n] = [6]Face{
+				FaceUp:    up,
+				FaceDown:  up ^ 1,
+				FaceFront: front,
+				FaceBack:  front ^ 1,
+				FaceRight: right,
+				FaceLeft:  right ^ 1,
+			}
+			orientationLookup[up][front] = Orientation(n)
+			n++
+		}
+	}
+
+	for o := range orientationTable {
+		for f := FaceUp; f <= FaceLeft; f++ {
+			g := Orientation(o).Face(f)
+			for i := 0; i < 9; i++ {
+				v := Orientation(o).toStandard(faceletVector(f, i))
+				for j := 0; j < 9; j++ {
+					if faceletVector(g, j) == v {
+						orientationFacelets[o][f][i] = byte(j)
+					}
+				}
+			}
+		}
+	}
+
+	orientationX = orientationLookup[FaceFront][FaceDown]
+	orientationXPrime = orientationLookup[FaceBack][FaceUp]
+	orientationY = orientationLookup[FaceUp][FaceRight]
+	orientationYPrime = orientationLookup[FaceUp][FaceLeft]
+	orientationZ = orientationLookup[FaceLeft][FaceFront]
+	orientationZPrime = orientationLookup[FaceRight][FaceFront]
+
+	// breadth first search for the shortest rotations to each orientation
+	found := [24]bool{StandardOrientation: true}
+	queue := []Orientation{StandardOrientation}
+	for len(queue) > 0 {
+		o := queue[0]
+		queue = queue[1:]
+		for m := RotationX; m <= RotationZ2; m++ {
+			p := o.Rotate(m)
+			if found[p] {
+				continue
+			}
+			found[p] = true
+			orientationRotations[p] = append(orientationRotations[o][:len(orientationRotations[o]):len(orientationRotations[o])], m)
+			queue = append(queue, p)
+		}
+	}
+
+	for o := range orientationCubies {
+		orientationCubies[o] = rotationCubie(Orientation(o))
+	}
+}
@@ -0,0 +1,56 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOrientation_Face(t *testing.T) {
+	for i := range orientationTable {
+		o := Orientation(i)
+		seen := make(map[Face]bool)
+		for f := FaceUp; f <= FaceLeft; f++ {
+			seen[o.Face(f)] = true
+			assert.Equal(t, o.Face(f)^1, o.Face(f^1))
+		}
+		assert.Len(t, seen, 6)
+	}
+	for f := FaceUp; f <= FaceLeft; f++ {
+		assert.Equal(t, f, StandardOrientation.Face(f))
+	}
+}
+
+func TestOrientation_Compose(t *testing.T) {
+	for _, i := range [][2]Orientation{
+		{orientationX, orientationXPrime},
+		{orientationY, orientationYPrime},
+		{orientationZ, orientationZPrime},
+	} {
+		assert.Equal(t, StandardOrientation, i[0].Compose(i[1]))
+		assert.Equal(t, StandardOrientation, i[0].Compose(i[0]).Compose(i[0]).Compose(i[0]))
+	}
+	assert.Equal(t, FaceFront, orientationX.Face(FaceUp))
+	assert.Equal(t, FaceRight, orientationY.Face(FaceFront))
+	assert.Equal(t, FaceLeft, orientationZ.Face(FaceUp))
+}
+
+func TestOrientation_Inverse(t *testing.T) {
+	for i := range orientationTable {
+		o := Orientation(i)
+		assert.Equal(t, StandardOrientation, o.Compose(o.Inverse()))
+		assert.Equal(t, StandardOrientation, o.Inverse().Compose(o))
+		for j := range orientationTable {
+			p := Orientation(j)
+			assert.Equal(t, p.Inverse().Compose(o.Inverse()), o.Compose(p).Inverse())
+		}
+	}
+}
+
+func TestNewOrientation(t *testing.T) {
+	assert.Equal(t, StandardOrientation, NewOrientation(FaceUp, FaceFront))
+	assert.Equal(t, Orientation(255), NewOrientation(FaceUp, FaceDown))
+	o := NewOrientation(FaceRight, FaceDown)
+	assert.Equal(t, FaceRight, o.Face(FaceUp))
+	assert.Equal(t, FaceDown, o.Face(FaceFront))
+	assert.Equal(t, StandardOrientation.Rotate(RotationZPrime).Rotate(RotationY), o)
+}
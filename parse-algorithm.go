@@ -0,0 +1,328 @@
+package rubiks_cube
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrUnexpectedToken = errors.New("unexpected token")
+	ErrUnclosedBracket = errors.New("unclosed bracket")
+	ErrRepeatTooLong   = errors.New("repeat too long")
+)
+
+// maxRepeatLength is the most moves a repeated group may expand to.
+const maxRepeatLength = 1 << 16
+
+// ParseError describes where an algorithm failed to parse. Line and Column
+// both start at 1 and point at the first character of Token.
+type ParseError struct {
+	Line   int
+	Column int
+	Token  string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s %q", e.Line, e.Column, e.Err, e.Token)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseAlgorithm reads a sequence of moves written in WCA or SiGN notation.
+//
+// Moves may be separated by whitespace or written next to each other. A group
+// of moves in brackets can be repeated with a count after the closing bracket
+// and inverted with a prime, for example "(R U R' U')3" or "(R U)'", up to
+// 65536 moves after repeating, otherwise ErrRepeatTooLong is returned. Square
+// brackets hold a commutator "[A, B]" meaning A B A' B' or a conjugate "[A: B]"
+// meaning A B A'. Groups can be nested and "//" starts a comment which runs to
+// the end of the line.
+func ParseAlgorithm(v string) (Algorithm, error) {
+	p := &algorithmParser{src: v, line: 1, col: 1}
+	moves, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.errorAt(p.mark(), string(p.peek()), ErrUnexpectedToken)
+	}
+	return moves, nil
+}
+
+// UnmarshalText reads the algorithm with ParseAlgorithm.
+func (a *Algorithm) UnmarshalText(text []byte) error {
+	z, err := ParseAlgorithm(string(text))
+	if err != nil {
+		return err
+	}
+	*a = z
+	return nil
+}
+
+// algorithmParser is a recursive descent parser over the runes of an
+// algorithm while keeping track of the current line and column.
+type algorithmParser struct {
+	src       string
+	pos       int
+	line, col int
+}
+
+// parserMark is a saved position in the source used for error reporting.
+type parserMark struct {
+	pos, line, col int
+}
+
+func (p *algorithmParser) mark() parserMark {
+	return parserMark{p.pos, p.line, p.col}
+}
+
+func (p *algorithmParser) errorAt(m parserMark, token string, err error) *ParseError {
+	return &ParseError{Line: m.line, Column: m.col, Token: token, Err: err}
+}
+
+// peek returns the next rune or utf8.RuneError at the end of the source.
+func (p *algorithmParser) peek() rune {
+	if p.pos >= len(p.src) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+	return r
+}
+
+func (p *algorithmParser) next() rune {
+	r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += n
+	if r == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+	return r
+}
+
+// skipSpace skips whitespace and comments.
+func (p *algorithmParser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch r := p.peek(); {
+		case unicode.IsSpace(r):
+			p.next()
+		case r == '/' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '/':
+			for p.pos < len(p.src) && p.peek() != '\n' {
+				p.next()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// parseSequence reads moves and groups until the end of the source or a
+// closing bracket or separator, which is left for the caller to read.
+func (p *algorithmParser) parseSequence() (Algorithm, error) {
+	var moves Algorithm
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return moves, nil
+		}
+		switch p.peek() {
+		case ')', ']', ',', ':':
+			return moves, nil
+		case '(':
+			group, err := p.parseGroup()
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, group...)
+		case '[':
+			group, err := p.parseBrackets()
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, group...)
+		default:
+			m, err := p.parseMove()
+			if err != nil {
+				return nil, err
+			}
+			moves = append(moves, m...)
+		}
+	}
+}
+
+// parseGroup reads moves in round brackets followed by an optional count and
+// prime.
+func (p *algorithmParser) parseGroup() (Algorithm, error) {
+	open := p.mark()
+	p.next()
+	moves, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectClose(open, "(", ')'); err != nil {
+		return nil, err
+	}
+	return p.parseRepeat(moves)
+}
+
+// parseBrackets reads a commutator or conjugate in square brackets followed by
+// an optional count and prime.
+func (p *algorithmParser) parseBrackets() (Algorithm, error) {
+	open := p.mark()
+	p.next()
+	a, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+	sep := p.mark()
+	if p.pos >= len(p.src) {
+		return nil, p.errorAt(open, "[", ErrUnclosedBracket)
+	}
+	r := p.next()
+	if r != ',' && r != ':' {
+		return nil, p.errorAt(sep, string(r), ErrUnexpectedToken)
+	}
+	b, err := p.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectClose(open, "[", ']'); err != nil {
+		return nil, err
+	}
+
+	moves := make(Algorithm, 0, 2*len(a)+2*len(b))
+	moves = append(moves, a...)
+	moves = append(moves, b...)
+	moves = append(moves, a.Inverse()...)
+	if r == ',' {
+		moves = append(moves, b.Inverse()...)
+	}
+	return p.parseRepeat(moves)
+}
+
+// expectClose reads the closing bracket c for the bracket opened at open.
+func (p *algorithmParser) expectClose(open parserMark, token string, c rune) error {
+	if p.pos >= len(p.src) {
+		return p.errorAt(open, token, ErrUnclosedBracket)
+	}
+	m := p.mark()
+	if r := p.next(); r != c {
+		return p.errorAt(m, string(r), ErrUnexpectedToken)
+	}
+	return nil
+}
+
+// parseRepeat reads the count and prime after a group and applies them.
+func (p *algorithmParser) parseRepeat(moves Algorithm) (Algorithm, error) {
+	m := p.mark()
+	count, ok := p.parseCount()
+	if !ok {
+		return nil, p.errorAt(m, p.src[m.pos:p.pos], ErrUnexpectedToken)
+	}
+	if count > 0 && len(moves) > maxRepeatLength/count {
+		return nil, p.errorAt(m, p.src[m.pos:p.pos], ErrRepeatTooLong)
+	}
+	if p.peek() == '\'' {
+		p.next()
+		moves = moves.Inverse()
+	}
+	out := make(Algorithm, 0, len(moves)*count)
+	for i := 0; i < count; i++ {
+		out = append(out, moves...)
+	}
+	return out, nil
+}
+
+// parseCount reads an optional number, which defaults to 1.
+func (p *algorithmParser) parseCount() (int, bool) {
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.next()
+	}
+	if start == p.pos {
+		return 1, true
+	}
+	n, err := strconv.Atoi(p.src[start:p.pos])
+	return n, err == nil
+}
+
+// parseMove reads a single move with an optional layer count before it and an
+// amount or prime after it. An amount which is a multiple of 4 returns no
+// moves.
+func (p *algorithmParser) parseMove() (Algorithm, error) {
+	start := p.mark()
+	invalid := func() error {
+		if p.pos == start.pos {
+			p.next()
+		}
+		return p.errorAt(start, p.src[start.pos:p.pos], ErrInvalidMove)
+	}
+
+	layers := 0
+	if r := p.peek(); r == '2' || r == '3' {
+		layers = int(p.next() - '0')
+	}
+	m, ok := moveLetterTable[p.peek()]
+	if !ok {
+		return nil, invalid()
+	}
+	p.next()
+	if m <= Left && p.peek() == 'w' {
+		p.next()
+		m += UpWide
+	}
+	switch {
+	case layers == 0:
+	case !m.Wide():
+		return nil, invalid()
+	case layers == 3:
+		m = wideRotationTable[m-UpWide]
+	}
+
+	amount, ok := p.parseCount()
+	if !ok {
+		return nil, invalid()
+	}
+	if p.peek() == '\'' {
+		p.next()
+		amount = -amount
+	}
+	switch (amount%4 + 4) % 4 {
+	case 0:
+		return nil, nil
+	case 2:
+		return Algorithm{m.Twice()}, nil
+	case 3:
+		return Algorithm{m.Reverse()}, nil
+	}
+	return Algorithm{m}, nil
+}
+
+// moveLetterTable maps the letter of each move to the clockwise move.
+var moveLetterTable = map[rune]Move{
+	'U': Up,
+	'D': Down,
+	'F': Front,
+	'B': Back,
+	'R': Right,
+	'L': Left,
+	'M': Middle,
+	'E': Equator,
+	'S': Standing,
+	'x': RotationX,
+	'y': RotationY,
+	'z': RotationZ,
+	'u': UpWide,
+	'd': DownWide,
+	'f': FrontWide,
+	'b': BackWide,
+	'r': RightWide,
+	'l': LeftWide,
+}
@@ -0,0 +1,61 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, i := range []struct {
+		in  string
+		out Algorithm
+	}{
+		{"", nil},
+		{"R U R' U'", Algorithm{Right, Up, RightPrime, UpPrime}},
+		{"RUR'U'", Algorithm{Right, Up, RightPrime, UpPrime}},
+		{"  R2\tU2'\nF3 B3' L4 D5", Algorithm{Right2, Up2, FrontPrime, Back, Down}},
+		{"r Rw' 2Lw2 3Rw 3Uw'", Algorithm{RightWide, RightWidePrime, LeftWide2, RotationX, RotationYPrime}},
+		{"M E' S2 x y' z2", Algorithm{Middle, EquatorPrime, Standing2, RotationX, RotationYPrime, RotationZ2}},
+		{"(R U)3", Algorithm{Right, Up, Right, Up, Right, Up}},
+		{"(R U2)'", Algorithm{Up2, RightPrime}},
+		{"(R (U F)2)2", Algorithm{Right, Up, Front, Up, Front, Right, Up, Front, Up, Front}},
+		{"(R U)0 F", Algorithm{Front}},
+		{"[R, U]", Algorithm{Right, Up, RightPrime, UpPrime}},
+		{"[R: U]", Algorithm{Right, Up, RightPrime}},
+		{"[F: [R, U]]", Algorithm{Front, Right, Up, RightPrime, UpPrime, FrontPrime}},
+		{"[R U: D]2", Algorithm{Right, Up, Down, UpPrime, RightPrime, Right, Up, Down, UpPrime, RightPrime}},
+		{"[R, U]'", Algorithm{Up, Right, UpPrime, RightPrime}},
+		{"R U // sexy move\n// second line\nR' U' // end", Algorithm{Right, Up, RightPrime, UpPrime}},
+	} {
+		moves, err := ParseAlgorithm(i.in)
+		assert.NoError(t, err, i.in)
+		assert.Equal(t, i.out, moves, i.in)
+	}
+}
+
+func TestParseAlgorithm_Error(t *testing.T) {
+	for _, i := range []struct {
+		in  string
+		err ParseError
+	}{
+		{"R U Q", ParseError{1, 5, "Q", ErrInvalidMove}},
+		{"R U\n  2R", ParseError{2, 3, "2R", ErrInvalidMove}},
+		{"R U'' D", ParseError{1, 5, "'", ErrInvalidMove}},
+		{"R U)", ParseError{1, 4, ")", ErrUnexpectedToken}},
+		{"R (U F", ParseError{1, 3, "(", ErrUnclosedBracket}},
+		{"[R U]", ParseError{1, 5, "]", ErrUnexpectedToken}},
+		{"(R, U)", ParseError{1, 3, ",", ErrUnexpectedToken}},
+		{"[R, U", ParseError{1, 1, "[", ErrUnclosedBracket}},
+		{"[R, U)", ParseError{1, 6, ")", ErrUnexpectedToken}},
+		{"R // comment\nU ☺", ParseError{2, 3, "☺", ErrInvalidMove}},
+		{"(R)9223372036854775807", ParseError{1, 4, "9223372036854775807", ErrRepeatTooLong}},
+		{"(R U)4611686018427387904", ParseError{1, 6, "4611686018427387904", ErrRepeatTooLong}},
+		{"((R U)256)256", ParseError{1, 11, "256", ErrRepeatTooLong}},
+	} {
+		_, err := ParseAlgorithm(i.in)
+		var parseErr *ParseError
+		if assert.ErrorAs(t, err, &parseErr, i.in) {
+			assert.Equal(t, i.err, *parseErr, i.in)
+		}
+	}
+}
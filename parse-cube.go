@@ -2,76 +2,231 @@ package rubiks_cube
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
-var ErrInvalidCubeState = errors.New("invalid cube state")
+var (
+	ErrInvalidCubeState = errors.New("invalid cube state")
+	ErrInvalidCenters   = fmt.Errorf("%w: centers do not match any orientation", ErrInvalidCubeState)
+)
+
+// ColorCountError is returned by ParseCube when a cube does not have exactly
+// nine facelets of each color. Counts holds the number of facelets of each
+// Color.
+type ColorCountError struct {
+	Counts [6]int
+}
+
+func (e *ColorCountError) Error() string {
+	var wrong []string
+	for c, n := range e.Counts {
+		if n != 9 {
+			wrong = append(wrong, fmt.Sprintf("%d %s", n, strings.ToLower(Color(c).String())))
+		}
+	}
+	return fmt.Sprintf("%s: wrong number of facelets: %s", ErrInvalidCubeState, strings.Join(wrong, ", "))
+}
 
+func (e *ColorCountError) Unwrap() error {
+	return ErrInvalidCubeState
+}
+
+// PieceError is returned by ParseCube when the colors of a corner or an edge
+// do not match any piece. Facelets are the positions of the stickers in the
+// diagram and Colors are the colors found there in the same order.
+type PieceError struct {
+	Facelets []Facelet
+	Colors   []Color
+}
+
+func (e *PieceError) Error() string {
+	piece := "edge"
+	if len(e.Facelets) == 3 {
+		piece = "corner"
+	}
+	facelets := make([]string, len(e.Facelets))
+	for i, f := range e.Facelets {
+		facelets[i] = f.String()
+	}
+	colors := make([]byte, len(e.Colors))
+	for i, c := range e.Colors {
+		colors[i] = c.Byte()
+	}
+	return fmt.Sprintf("%s: invalid %s %s with colors %s", ErrInvalidCubeState, piece, strings.Join(facelets, " "), colors)
+}
+
+func (e *PieceError) Unwrap() error {
+	return ErrInvalidCubeState
+}
+
+// ParseCube reads a cube from the diagram shown on RubiksCube.Face. The
+// orientation is found from the colors of the centers and the cube is checked
+// with RubiksCube.Validate so unsolvable cubes are rejected.
+//
+// A *FaceletError is returned for a diagram which cannot be read, a
+// *ColorCountError if there are not nine facelets of each color and a
+// *PieceError for stickers which do not form a real corner or edge.
 func ParseCube(v string) (RubiksCube, error) {
 	faces, err := ParseFaces(v)
 	if err != nil {
 		return RubiksCube{}, err
 	}
-
-	cube := RubiksCube{
-		// corners
-		[4]CornerCubelet{
-			DetectCorner(faces[FaceUp][8], faces[FaceFront][2], faces[FaceRight][0]),
-			DetectCorner(faces[FaceUp][2], faces[FaceBack][0], faces[FaceRight][2]),
-			DetectCorner(faces[FaceDown][8], faces[FaceBack][6], faces[FaceRight][8]),
-			DetectCorner(faces[FaceDown][2], faces[FaceFront][8], faces[FaceRight][6]),
-		},
-		[4]CornerCubelet{
-			DetectCorner(faces[FaceUp][6], faces[FaceFront][0], faces[FaceLeft][2]),
-			DetectCorner(faces[FaceUp][0], faces[FaceBack][2], faces[FaceLeft][0]),
-			DetectCorner(faces[FaceDown][6], faces[FaceBack][8], faces[FaceLeft][6]),
-			DetectCorner(faces[FaceDown][0], faces[FaceFront][6], faces[FaceLeft][8]),
-		},
-
-		// edges
-		[4]EdgeCubelet{
-			DetectEdge(faces[FaceUp][5], faces[FaceRight][1]),
-			DetectEdge(faces[FaceBack][3], faces[FaceRight][5]),
-			DetectEdge(faces[FaceDown][5], faces[FaceRight][7]),
-			DetectEdge(faces[FaceFront][5], faces[FaceRight][3]),
-		},
-		[4]EdgeCubelet{
-			DetectEdge(faces[FaceUp][3], faces[FaceLeft][1]),
-			DetectEdge(faces[FaceBack][5], faces[FaceLeft][3]),
-			DetectEdge(faces[FaceDown][3], faces[FaceLeft][7]),
-			DetectEdge(faces[FaceFront][3], faces[FaceLeft][5]),
-		},
-		[4]EdgeCubelet{
-			DetectEdge(faces[FaceUp][7], faces[FaceFront][1]),
-			DetectEdge(faces[FaceUp][1], faces[FaceBack][1]),
-			DetectEdge(faces[FaceDown][7], faces[FaceBack][7]),
-			DetectEdge(faces[FaceDown][1], faces[FaceFront][7]),
-		},
-	}
-	for _, i := range cube.RightCorners {
-		if i == 255 {
-			return RubiksCube{}, ErrInvalidCubeState
+	var counts [6]int
+	for _, face := range faces {
+		for _, c := range face {
+			counts[c]++
+		}
+	}
+	for _, n := range counts {
+		if n != 9 {
+			return RubiksCube{}, &ColorCountError{counts}
 		}
 	}
-	for _, i := range cube.LeftCorners {
-		if i == 255 {
-			return RubiksCube{}, ErrInvalidCubeState
+	orientation, ok := detectOrientation(faces)
+	if !ok {
+		return RubiksCube{}, ErrInvalidCenters
+	}
+	standard := standardFaces(orientation, faces)
+
+	cube, bad := detectCubelets(standard)
+	if bad != nil {
+		return RubiksCube{}, newPieceError(orientation, faces, bad)
+	}
+	cube.Orientation = orientation
+
+	if err := cube.Validate(); err != nil {
+		return RubiksCube{}, err
+	}
+
+	return cube, nil
+}
+
+// cornerFaceletTable and edgeFaceletTable are the facelets of each piece in the
+// standard orientation, in the same order as RubiksCube.corners and
+// RubiksCube.edges. The colors are in the order used by DetectCorner and
+// DetectEdge.
+var cornerFaceletTable = [8][3]Facelet{
+	{{FaceUp, 8}, {FaceFront, 2}, {FaceRight, 0}},
+	{{FaceUp, 2}, {FaceBack, 0}, {FaceRight, 2}},
+	{{FaceDown, 8}, {FaceBack, 6}, {FaceRight, 8}},
+	{{FaceDown, 2}, {FaceFront, 8}, {FaceRight, 6}},
+	{{FaceUp, 6}, {FaceFront, 0}, {FaceLeft, 2}},
+	{{FaceUp, 0}, {FaceBack, 2}, {FaceLeft, 0}},
+	{{FaceDown, 6}, {FaceBack, 8}, {FaceLeft, 6}},
+	{{FaceDown, 0}, {FaceFront, 6}, {FaceLeft, 8}},
+}
+
+var edgeFaceletTable = [12][2]Facelet{
+	{{FaceUp, 5}, {FaceRight, 1}},
+	{{FaceBack, 3}, {FaceRight, 5}},
+	{{FaceDown, 5}, {FaceRight, 7}},
+	{{FaceFront, 5}, {FaceRight, 3}},
+	{{FaceUp, 3}, {FaceLeft, 1}},
+	{{FaceBack, 5}, {FaceLeft, 3}},
+	{{FaceDown, 3}, {FaceLeft, 7}},
+	{{FaceFront, 3}, {FaceLeft, 5}},
+	{{FaceUp, 7}, {FaceFront, 1}},
+	{{FaceUp, 1}, {FaceBack, 1}},
+	{{FaceDown, 7}, {FaceBack, 7}},
+	{{FaceDown, 1}, {FaceFront, 7}},
+}
+
+// detectCubelets finds the cubelets shown on the faces of a cube held in the
+// standard orientation. The facelets of the first piece which is not a real
+// corner or edge are returned if any are found.
+func detectCubelets(faces CubeFaceData) (RubiksCube, []Facelet) {
+	var cube RubiksCube
+	var corners [8]CornerCubelet
+	for i, s := range cornerFaceletTable {
+		corners[i] = detectCorner(cornerSlotPositionTable[i], faces.at(s[0]), faces.at(s[1]), faces.at(s[2]))
+		if !corners[i].Valid() {
+			return cube, s[:]
 		}
 	}
-	for _, i := range cube.RightEdges {
-		if i == 255 {
-			return RubiksCube{}, ErrInvalidCubeState
+	var edges [12]EdgeCubelet
+	for i, s := range edgeFaceletTable {
+		edges[i] = DetectEdge(faces.at(s[0]), faces.at(s[1]))
+		if !edges[i].Valid() {
+			return cube, s[:]
 		}
 	}
-	for _, i := range cube.LeftEdges {
-		if i == 255 {
-			return RubiksCube{}, ErrInvalidCubeState
+	cube.setCorners(corners)
+	cube.setEdges(edges)
+	return cube, nil
+}
+
+func (c CubeFaceData) at(f Facelet) Color {
+	return c[f.Face][f.Index]
+}
+
+// newPieceError creates a PieceError for the standard facelets of a piece on a
+// diagram of a cube held in the orientation o.
+func newPieceError(o Orientation, faces CubeFaceData, standard []Facelet) *PieceError {
+	e := &PieceError{
+		Facelets: make([]Facelet, len(standard)),
+		Colors:   make([]Color, len(standard)),
+	}
+	for i, s := range standard {
+		f := o.physicalFacelet(s)
+		e.Facelets[i] = f
+		e.Colors[i] = faces.at(f)
+	}
+	return e
+}
+
+// physicalFacelet finds the facelet shown on the diagram of a cube held in the
+// orientation o for the facelet s of the standard orientation.
+func (o Orientation) physicalFacelet(s Facelet) Facelet {
+	for f := FaceUp; f <= FaceLeft; f++ {
+		if o.Face(f) != s.Face {
+			continue
+		}
+		for i, j := range orientationFacelets[o][f] {
+			if int(j) == s.Index {
+				return Facelet{f, i}
+			}
 		}
 	}
-	for _, i := range cube.MiddleEdges {
-		if i == 255 {
-			return RubiksCube{}, ErrInvalidCubeState
+	return Facelet{s.Face, s.Index}
+}
+
+var centerColorTable = [6]Color{
+	FaceUp:    White,
+	FaceDown:  Yellow,
+	FaceFront: Orange,
+	FaceBack:  Red,
+	FaceRight: Green,
+	FaceLeft:  Blue,
+}
+
+// detectOrientation finds the orientation of the cube from the colors of the
+// centers.
+func detectOrientation(faces CubeFaceData) (Orientation, bool) {
+	for i := range orientationTable {
+		o := Orientation(i)
+		ok := true
+		for f := FaceUp; f <= FaceLeft; f++ {
+			if faces[f][4] != centerColorTable[o.Face(f)] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return o, true
 		}
 	}
+	return 0, false
+}
 
-	return cube, nil
+// standardFaces rearranges the faces of a cube held in the orientation o into
+// the faces of the standard orientation.
+func standardFaces(o Orientation, faces CubeFaceData) (z CubeFaceData) {
+	for f := FaceUp; f <= FaceLeft; f++ {
+		g := o.Face(f)
+		for i := 0; i < 9; i++ {
+			z[g][orientationFacelets[o][f][i]] = faces[f][i]
+		}
+	}
+	return
 }
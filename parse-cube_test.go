@@ -2,6 +2,8 @@ package rubiks_cube
 
 import (
 	"github.com/stretchr/testify/assert"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -19,3 +21,90 @@ bbbooogggrrr
 	assert.NoError(t, err)
 	assert.Equal(t, NewSolvedCube(), cube)
 }
+
+const solvedDiagram = `   www
+   www
+   www
+bbbooogggrrr
+bbbooogggrrr
+bbbooogggrrr
+   yyy
+   yyy
+   yyy
+`
+
+func TestParseFaces_Error(t *testing.T) {
+	for _, i := range []struct {
+		in  string
+		err FaceletError
+	}{
+		{strings.Replace(solvedDiagram, "bbbooo", "bbboxo", 1), FaceletError{4, 5, 'x', Facelet{FaceFront, 1}}},
+		{strings.Replace(solvedDiagram, "   yyy\n   yyy\n", "   yyy\n   yyY\n", 1), FaceletError{8, 6, 'Y', Facelet{FaceDown, 5}}},
+		{strings.Replace(solvedDiagram, "   www", "  www", 1), FaceletError{1, 3, 'w', Facelet{FaceUp, -1}}},
+		{strings.Replace(solvedDiagram, "   www", "   www   ", 1), FaceletError{1, 10, 0, Facelet{FaceUp, -1}}},
+		{strings.Replace(solvedDiagram, "   www", "   www☺", 1), FaceletError{1, 7, '☺', Facelet{FaceUp, -1}}},
+		{strings.Replace(solvedDiagram, "gggrrr", "gggrr", 1), FaceletError{4, 12, 0, Facelet{FaceBack, 2}}},
+		{strings.Replace(solvedDiagram, "gggrrr", "gggrrrr", 1), FaceletError{4, 13, 'r', Facelet{FaceUp, -1}}},
+		{solvedDiagram[:len(solvedDiagram)-7], FaceletError{9, 1, 0, Facelet{FaceUp, -1}}},
+	} {
+		_, err := ParseFaces(i.in)
+		assert.ErrorIs(t, err, ErrInvalidCubeString)
+		var faceletErr *FaceletError
+		if assert.ErrorAs(t, err, &faceletErr, i.in) {
+			assert.Equal(t, i.err, *faceletErr, i.in)
+		}
+	}
+}
+
+func TestParseCube_ColorCountError(t *testing.T) {
+	_, err := ParseCube(strings.Replace(solvedDiagram, "bbbooo", "bbbwoo", 1))
+	assert.ErrorIs(t, err, ErrInvalidCubeState)
+	var countErr *ColorCountError
+	if assert.ErrorAs(t, err, &countErr) {
+		assert.Equal(t, [6]int{10, 9, 8, 9, 9, 9}, countErr.Counts)
+		assert.Equal(t, "invalid cube state: wrong number of facelets: 10 white, 8 orange", countErr.Error())
+	}
+}
+
+func TestParseCube_PieceError(t *testing.T) {
+	// swap the white sticker of UFR with the orange sticker of UFL
+	diagram := strings.Replace(solvedDiagram, "   www\nbbbooo", "   wwo\nbbbwoo", 1)
+	_, err := ParseCube(diagram)
+	assert.ErrorIs(t, err, ErrInvalidCubeState)
+	var pieceErr *PieceError
+	if assert.ErrorAs(t, err, &pieceErr) {
+		assert.Equal(t, []Facelet{{FaceUp, 8}, {FaceFront, 2}, {FaceRight, 0}}, pieceErr.Facelets)
+		assert.Equal(t, []Color{Orange, Orange, Green}, pieceErr.Colors)
+		assert.Equal(t, "invalid cube state: invalid corner U8 F2 R0 with colors oog", pieceErr.Error())
+	}
+
+	// the facelets are where they are shown on the diagram of a held cube
+	lines := strings.Split(NewSolvedCube().Apply(mustParseAlgorithm(t, "x y")).String(), "\n")
+	u8, f0 := []byte(lines[2]), []byte(lines[3])
+	u8[5], f0[3] = f0[3], u8[5]
+	lines[2], lines[3] = string(u8), string(f0)
+	_, err = ParseCube(strings.Join(lines, "\n"))
+	if assert.ErrorAs(t, err, &pieceErr) {
+		facelets := append([]Facelet(nil), pieceErr.Facelets...)
+		sort.Slice(facelets, func(i, j int) bool { return facelets[i].Face < facelets[j].Face })
+		assert.Contains(t, [][]Facelet{
+			{{FaceUp, 8}, {FaceFront, 2}, {FaceRight, 0}},
+			{{FaceUp, 6}, {FaceFront, 0}, {FaceLeft, 2}},
+		}, facelets)
+		for i, f := range pieceErr.Facelets {
+			line, col := diagramPosition(f)
+			assert.Equal(t, lines[line][col], pieceErr.Colors[i].Byte())
+		}
+	}
+}
+
+func diagramPosition(f Facelet) (line, col int) {
+	for line = 0; line < 9; line++ {
+		for col = 0; col < 12; col++ {
+			if g, ok := diagramFacelet(line, col); ok && g == f {
+				return
+			}
+		}
+	}
+	return -1, -1
+}
@@ -0,0 +1,178 @@
+package rubiks_cube
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+)
+
+// patternsMagic starts every pattern database file, which otherwise has the
+// same layout as a tables file. patternsVersion must be changed whenever the
+// layout or contents of the pattern databases change.
+const (
+	patternsMagic   = "RCPD"
+	patternsVersion = 1
+)
+
+// PatternsEnv is the environment variable holding the file used by
+// DefaultPatternDatabases.
+const PatternsEnv = "RUBIKS_CUBE_PATTERNS"
+
+// PatternDatabases hold the exact number of face moves needed to solve parts
+// of the cube, used as lower bounds by SolveOptimal. They take several seconds
+// to generate, so they are only made when the optimal solver is used.
+//
+// Edges only follows the flip and the four edges of the middle layer, so it is
+// a much weaker bound than the edge tables of Korf's solver, and SolveOptimal
+// can take a long time for a cube far from solved.
+type PatternDatabases struct {
+	// Corners holds the distance of every position of the corners, indexed
+	// by CornerPermutation multiplied by TwistCount plus Twist.
+	Corners PatternTable
+
+	// Edges holds the distance of the flip of every edge together with the
+	// positions and order of the FR, FL, BL and BR edges, indexed by Flip
+	// multiplied by SliceSortedCount plus SliceSorted.
+	Edges PatternTable
+}
+
+// unknownPattern marks an entry of a PatternTable which has not been reached.
+const unknownPattern = 0xf
+
+// PatternTable holds a distance from 0 to 14 for each entry, packed two
+// entries to a byte so the large pattern databases take half the memory of a
+// PruneTable.
+type PatternTable []byte
+
+// Get returns the distance of entry i.
+func (t PatternTable) Get(i int) byte {
+	return t[i/2] >> (i % 2 * 4) & 0xf
+}
+
+func (t PatternTable) set(i int, d byte) {
+	shift := i % 2 * 4
+	t[i/2] = t[i/2]&^(0xf<<shift) | d<<shift
+}
+
+// generatePatternTable finds the distance of each combination of the
+// coordinates a and b from the solved state with a breadth first search using
+// every face move. Each depth is found by scanning the whole table, which is
+// much faster than a queue for tables this large.
+func generatePatternTable(a, b MoveTable) PatternTable {
+	n := len(b)
+	size := len(a) * n
+	table := make(PatternTable, (size+1)/2)
+	for i := range table {
+		table[i] = 0xff
+	}
+	table.set(0, 0)
+	for depth, found := byte(0), 1; found < size; depth++ {
+		// once most entries are found it is faster to look for a neighbour
+		// at this depth from each unknown entry
+		backward := found > size/4
+		added := 0
+		for x := range a {
+			for y := range b {
+				i := x*n + y
+				d := table.Get(i)
+				if backward {
+					if d != unknownPattern {
+						continue
+					}
+					for m := 0; m < faceMoveCount; m++ {
+						if table.Get(int(a[x][m])*n+int(b[y][m])) == depth {
+							table.set(i, depth+1)
+							added++
+							break
+						}
+					}
+					continue
+				}
+				if d != depth {
+					continue
+				}
+				for m := 0; m < faceMoveCount; m++ {
+					j := int(a[x][m])*n + int(b[y][m])
+					if table.Get(j) == unknownPattern {
+						table.set(j, depth+1)
+						added++
+					}
+				}
+			}
+		}
+		if added == 0 {
+			break
+		}
+		found += added
+	}
+	return table
+}
+
+// GeneratePatternDatabases builds the pattern databases from the move tables
+// t.
+func GeneratePatternDatabases(t *Tables) *PatternDatabases {
+	return &PatternDatabases{
+		Corners: generatePatternTable(t.CornerPermutationMove, t.TwistMove),
+		Edges:   generatePatternTable(t.FlipMove, t.SliceSortedMove),
+	}
+}
+
+var (
+	defaultPatternsOnce sync.Once
+	defaultPatterns     *PatternDatabases
+)
+
+// DefaultPatternDatabases returns the pattern databases shared by the optimal
+// solver. Like DefaultTables, the first call loads them from the file named by
+// the RUBIKS_CUBE_PATTERNS environment variable or generates them.
+func DefaultPatternDatabases() *PatternDatabases {
+	defaultPatternsOnce.Do(func() {
+		if path := os.Getenv(PatternsEnv); path != "" {
+			defaultPatterns, _ = LoadPatternDatabases(path)
+		}
+		if defaultPatterns == nil {
+			defaultPatterns = GeneratePatternDatabases(DefaultTables())
+		}
+	})
+	return defaultPatterns
+}
+
+// LoadPatternDatabases reads the pattern databases from the file at path, or
+// generates them with DefaultTables and writes them to path, see LoadTables.
+func LoadPatternDatabases(path string) (*PatternDatabases, error) {
+	f, err := os.Open(path)
+	if err == nil {
+		p, err := ReadPatternDatabases(bufio.NewReader(f))
+		f.Close()
+		if err == nil {
+			return p, nil
+		}
+	}
+
+	p := GeneratePatternDatabases(DefaultTables())
+	return p, writeTablesFile(path, p)
+}
+
+func (p *PatternDatabases) parts() []tablePart {
+	return []tablePart{
+		{&p.Corners, (CornerPermutationCount*TwistCount + 1) / 2},
+		{&p.Edges, (FlipCount*SliceSortedCount + 1) / 2},
+	}
+}
+
+// WriteTo writes the pattern databases in the format of Tables.WriteTo
+// starting with "RCPD".
+func (p *PatternDatabases) WriteTo(w io.Writer) (int64, error) {
+	return writeParts(w, patternsMagic, patternsVersion, p.parts())
+}
+
+// ReadPatternDatabases reads pattern databases written by
+// PatternDatabases.WriteTo.
+func ReadPatternDatabases(r io.Reader) (*PatternDatabases, error) {
+	p := new(PatternDatabases)
+	if err := readParts(r, patternsMagic, patternsVersion, p.parts()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,78 @@
+package rubiks_cube
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneratePatternDatabases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the pattern databases take several seconds to generate")
+	}
+	tables := DefaultTables()
+	patterns := DefaultPatternDatabases()
+
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 100; i++ {
+		a := randomAlgorithm(r, all, r.Intn(12))
+		c := NewSolvedCube().Apply(a).CubieCube()
+
+		// the pattern databases are lower bounds
+		assert.LessOrEqual(t, int(patterns.Corners.Get(c.CornerPermutation()*TwistCount+c.Twist())), len(a))
+		assert.LessOrEqual(t, int(patterns.Edges.Get(c.Flip()*SliceSortedCount+c.SliceSorted())), len(a))
+	}
+
+	c := NewSolvedCube().Move(Right).CubieCube()
+	assert.Equal(t, byte(0), patterns.Corners.Get(0))
+	assert.Equal(t, byte(1), patterns.Corners.Get(c.CornerPermutation()*TwistCount+c.Twist()))
+	assert.Equal(t, byte(0), patterns.Edges.Get(0))
+	assert.Equal(t, byte(1), patterns.Edges.Get(c.Flip()*SliceSortedCount+c.SliceSorted()))
+	assert.Equal(t, byte(1), patterns.Edges.Get(int(tables.FlipMove[0][Front])*SliceSortedCount+int(tables.SliceSortedMove[0][Front])))
+	for i := 0; i < CornerPermutationCount*TwistCount; i += 997 {
+		assert.NotEqual(t, byte(unknownPattern), patterns.Corners.Get(i))
+	}
+}
+
+func TestPatternTable(t *testing.T) {
+	table := make(PatternTable, 2)
+	table.set(0, 3)
+	table.set(1, 14)
+	table.set(2, 7)
+	assert.Equal(t, PatternTable{0xe3, 0x07}, table)
+	assert.Equal(t, byte(14), table.Get(1))
+	table.set(1, 2)
+	assert.Equal(t, byte(3), table.Get(0))
+	assert.Equal(t, byte(2), table.Get(1))
+}
+
+func TestPatternDatabases_WriteTo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the pattern databases take several seconds to generate")
+	}
+	patterns := DefaultPatternDatabases()
+	var buf bytes.Buffer
+	n, err := patterns.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, patternsMagic, string(buf.Bytes()[:4]))
+	assert.Equal(t, byte(patternsVersion), buf.Bytes()[4])
+
+	read, err := ReadPatternDatabases(bytes.NewReader(buf.Bytes()))
+	if assert.NoError(t, err) {
+		assert.True(t, bytes.Equal(patterns.Corners, read.Corners))
+		assert.True(t, bytes.Equal(patterns.Edges, read.Edges))
+	}
+
+	// a tables file is not a pattern database file
+	buf.Reset()
+	_, err = DefaultTables().WriteTo(&buf)
+	assert.NoError(t, err)
+	_, err = ReadPatternDatabases(&buf)
+	assert.ErrorIs(t, err, ErrTablesFormat)
+}
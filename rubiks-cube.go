@@ -7,6 +7,7 @@ import (
 // RubiksCube stores the state of a Rubik's Cube using the type and rotation of each corner and edge cubelet.
 //
 // The cube is stored in the rotation where for the centers U = white, D = yellow, F = orange, B = red, R = green, L = blue
+// and Orientation records which way the cube is actually being held.
 //
 // RightCorners is clockwise around the green face starting at the top right.
 // RightEdges is clockwise around the green face starting at the top right.
@@ -14,6 +15,13 @@ import (
 // LeftEdges is anti-clockwise around the blue face starting at the top left.
 // MiddleEdges is clockwise around the green face for the final four edge pieces.
 //
+// CornerSlot and EdgeSlot name each of these positions and can be used with
+// CornerAt, EdgeAt, SetCorner and SetEdge.
+//
+// The corner positions alternate in handedness (see CornerPosition) starting
+// with CornerAntiClockwise for RightCorners and CornerClockwise for
+// LeftCorners.
+//
 // Due to the design of the storage type, the solved state of every cubelet has
 // the 0 rotation/facing value.
 type RubiksCube struct {
@@ -22,6 +30,7 @@ type RubiksCube struct {
 	RightEdges   [4]EdgeCubelet
 	LeftEdges    [4]EdgeCubelet
 	MiddleEdges  [4]EdgeCubelet
+	Orientation  Orientation
 }
 
 // NewSolvedCube forms a solved Rubik's cube. The default facing state of each
@@ -63,63 +72,217 @@ func NewSolvedCube() RubiksCube {
 			EdgeCubelet(EdgeYellowRed),
 			EdgeCubelet(EdgeYellowOrange),
 		},
+
+		StandardOrientation,
 	}
 }
 
+// cornerSlotPositionTable is the handedness of each corner position in the order
+// of RightCorners followed by LeftCorners.
+var cornerSlotPositionTable = [8]CornerPosition{
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+	CornerClockwise,
+	CornerAntiClockwise,
+}
+
+// edgeSlotPositionTable is the position of each edge in the order of
+// RightEdges, LeftEdges and then MiddleEdges.
+var edgeSlotPositionTable = [12]EdgePosition{
+	EdgeTopRight,
+	EdgeFrontRight,
+	EdgeTopRight,
+	EdgeFrontRight,
+	EdgeTopRight,
+	EdgeFrontRight,
+	EdgeTopRight,
+	EdgeFrontRight,
+	EdgeTopFront,
+	EdgeTopFront,
+	EdgeTopFront,
+	EdgeTopFront,
+}
+
+// corners returns the corner cubelets in the order of RightCorners followed by
+// LeftCorners.
+func (r RubiksCube) corners() (z [8]CornerCubelet) {
+	copy(z[:4], r.RightCorners[:])
+	copy(z[4:], r.LeftCorners[:])
+	return
+}
+
+// edges returns the edge cubelets in the order of RightEdges, LeftEdges and
+// then MiddleEdges.
+func (r RubiksCube) edges() (z [12]EdgeCubelet) {
+	copy(z[:4], r.RightEdges[:])
+	copy(z[4:8], r.LeftEdges[:])
+	copy(z[8:], r.MiddleEdges[:])
+	return
+}
+
+// setCorners is the reverse of corners.
+func (r *RubiksCube) setCorners(z [8]CornerCubelet) {
+	copy(r.RightCorners[:], z[:4])
+	copy(r.LeftCorners[:], z[4:])
+}
+
+// setEdges is the reverse of edges.
+func (r *RubiksCube) setEdges(z [12]EdgeCubelet) {
+	copy(r.RightEdges[:], z[:4])
+	copy(r.LeftEdges[:], z[4:8])
+	copy(r.MiddleEdges[:], z[8:])
+}
+
+// Move applies the move m to the cube as it is currently being held.
 func (r RubiksCube) Move(m Move) RubiksCube {
 	switch m {
-	case Up, UpPrime:
-		return r.RotateUp(m.Prime())
-	case Down, DownPrime:
-		return r.RotateDown(m.Prime())
-	case Front, FrontPrime:
-		return r.RotateFront(m.Prime())
-	case Back, BackPrime:
-		return r.RotateBack(m.Prime())
-	case Right, RightPrime:
-		return r.RotateRight(m.Prime())
-	case Left, LeftPrime:
-		return r.RotateLeft(m.Prime())
+	case Up, Down, Front, Back, Right, Left, UpPrime, DownPrime, FrontPrime, BackPrime, RightPrime, LeftPrime:
+		return r.rotateFace(r.Orientation.Face(Face(m%6)), m.Prime())
+	case Up2, Down2, Front2, Back2, Right2, Left2, Middle2, Equator2, Standing2:
+		q := m.Quarter()
+		return r.Move(q).Move(q)
+	case Middle:
+		return r.rotateSlice(FaceLeft, orientationXPrime)
+	case MiddlePrime:
+		return r.rotateSlice(FaceRight, orientationX)
+	case Equator:
+		return r.rotateSlice(FaceDown, orientationYPrime)
+	case EquatorPrime:
+		return r.rotateSlice(FaceUp, orientationY)
+	case Standing:
+		return r.rotateSlice(FaceFront, orientationZ)
+	case StandingPrime:
+		return r.rotateSlice(FaceBack, orientationZPrime)
+	case RotationX, RotationY, RotationZ, RotationXPrime, RotationYPrime, RotationZPrime, RotationX2, RotationY2, RotationZ2:
+		r.Orientation = r.Orientation.Rotate(m)
+	case UpWide, DownWide, FrontWide, BackWide, RightWide, LeftWide:
+		return r.Move(m - UpWide).Move(wideSliceTable[m-UpWide])
+	case UpWidePrime, DownWidePrime, FrontWidePrime, BackWidePrime, RightWidePrime, LeftWidePrime:
+		return r.Move(m - UpWide).Move(wideSliceTable[m-UpWidePrime].Reverse())
+	case UpWide2, DownWide2, FrontWide2, BackWide2, RightWide2, LeftWide2:
+		q := m.Quarter()
+		return r.Move(q).Move(q)
+	}
+	return r
+}
+
+// wideSliceTable is the slice turned with each face by a wide move.
+var wideSliceTable = [6]Move{
+	EquatorPrime,
+	Equator,
+	Standing,
+	StandingPrime,
+	MiddlePrime,
+	Middle,
+}
+
+// Hold returns the cube held in the orientation o. The cubelets are unchanged
+// so this only changes the faces seen by Face and String and the faces turned
+// by Move.
+func (r RubiksCube) Hold(o Orientation) RubiksCube {
+	r.Orientation = o
+	return r
+}
+
+// rotateFace turns the face of the standard orientation f.
+func (r RubiksCube) rotateFace(f Face, prime bool) RubiksCube {
+	switch f {
+	case FaceUp:
+		return r.RotateUp(prime)
+	case FaceDown:
+		return r.RotateDown(prime)
+	case FaceFront:
+		return r.RotateFront(prime)
+	case FaceBack:
+		return r.RotateBack(prime)
+	case FaceRight:
+		return r.RotateRight(prime)
+	case FaceLeft:
+		return r.RotateLeft(prime)
 	}
 	return r
 }
 
+// rotateSlice turns the middle slice between the face f and its opposite face
+// in the same direction as the face f. The slice is turned by turning the
+// outer faces the other way and then turning the whole cube to orientation t,
+// which leaves the cubelets in the same place relative to the centers.
+func (r RubiksCube) rotateSlice(f Face, t Orientation) RubiksCube {
+	r = r.rotateFace(r.Orientation.Face(f), true)
+	r = r.rotateFace(r.Orientation.Face(f^1), false)
+	r.Orientation = r.Orientation.Compose(t)
+	return r
+}
+
+// RotateUp turns the face with the white center, this ignores the orientation
+// of the cube. The other Rotate methods turn the face with the matching center
+// in the standard orientation.
 func (r RubiksCube) RotateUp(prime bool) RubiksCube {
+	cycleCorners(prime, TurnOfUpDown, &r.RightCorners[0], &r.LeftCorners[0], &r.LeftCorners[1], &r.RightCorners[1])
+	turnEdge(&r.MiddleEdges[0], EdgeTopFront, TurnOfUpDown)
+	turnEdge(&r.LeftEdges[0], EdgeTopRight, TurnOfUpDown)
+	turnEdge(&r.MiddleEdges[1], EdgeTopFront, TurnOfUpDown)
+	turnEdge(&r.RightEdges[0], EdgeTopRight, TurnOfUpDown)
+	cycleItems(prime, &r.MiddleEdges[0], &r.LeftEdges[0], &r.MiddleEdges[1], &r.RightEdges[0])
 	return r
 }
 
 func (r RubiksCube) RotateDown(prime bool) RubiksCube {
+	cycleCorners(prime, TurnOfUpDown, &r.LeftCorners[3], &r.RightCorners[3], &r.RightCorners[2], &r.LeftCorners[2])
+	turnEdge(&r.MiddleEdges[3], EdgeTopFront, TurnOfUpDown)
+	turnEdge(&r.RightEdges[2], EdgeTopRight, TurnOfUpDown)
+	turnEdge(&r.MiddleEdges[2], EdgeTopFront, TurnOfUpDown)
+	turnEdge(&r.LeftEdges[2], EdgeTopRight, TurnOfUpDown)
+	cycleItems(prime, &r.MiddleEdges[3], &r.RightEdges[2], &r.MiddleEdges[2], &r.LeftEdges[2])
 	return r
 }
 
 func (r RubiksCube) RotateFront(prime bool) RubiksCube {
+	cycleCorners(prime, TurnOfFrontBack, &r.LeftCorners[0], &r.RightCorners[0], &r.RightCorners[3], &r.LeftCorners[3])
+	turnEdge(&r.MiddleEdges[0], EdgeTopFront, TurnOfFrontBack)
+	turnEdge(&r.RightEdges[3], EdgeFrontRight, TurnOfFrontBack)
+	turnEdge(&r.MiddleEdges[3], EdgeTopFront, TurnOfFrontBack)
+	turnEdge(&r.LeftEdges[3], EdgeFrontRight, TurnOfFrontBack)
+	cycleItems(prime, &r.MiddleEdges[0], &r.RightEdges[3], &r.MiddleEdges[3], &r.LeftEdges[3])
 	return r
 }
 
 func (r RubiksCube) RotateBack(prime bool) RubiksCube {
+	cycleCorners(prime, TurnOfFrontBack, &r.RightCorners[1], &r.LeftCorners[1], &r.LeftCorners[2], &r.RightCorners[2])
+	turnEdge(&r.MiddleEdges[1], EdgeTopFront, TurnOfFrontBack)
+	turnEdge(&r.LeftEdges[1], EdgeFrontRight, TurnOfFrontBack)
+	turnEdge(&r.MiddleEdges[2], EdgeTopFront, TurnOfFrontBack)
+	turnEdge(&r.RightEdges[1], EdgeFrontRight, TurnOfFrontBack)
+	cycleItems(prime, &r.MiddleEdges[1], &r.LeftEdges[1], &r.MiddleEdges[2], &r.RightEdges[1])
 	return r
 }
 
 func (r RubiksCube) RotateRight(prime bool) RubiksCube {
 	cycleCorners(prime, TurnOfRightLeft, &r.RightCorners[0], &r.RightCorners[1], &r.RightCorners[2], &r.RightCorners[3])
-	cycleItems(prime, &r.RightEdges[0], &r.RightEdges[1], &r.RightEdges[2], &r.RightEdges[3])
 	turnEdge(&r.RightEdges[0], EdgeTopRight, TurnOfRightLeft)
 	turnEdge(&r.RightEdges[1], EdgeFrontRight, TurnOfRightLeft)
 	turnEdge(&r.RightEdges[2], EdgeTopRight, TurnOfRightLeft)
 	turnEdge(&r.RightEdges[3], EdgeFrontRight, TurnOfRightLeft)
+	cycleItems(prime, &r.RightEdges[0], &r.RightEdges[1], &r.RightEdges[2], &r.RightEdges[3])
 	return r
 }
 
 func (r RubiksCube) RotateLeft(prime bool) RubiksCube {
 	cycleCorners(prime, TurnOfRightLeft, &r.LeftCorners[1], &r.LeftCorners[0], &r.LeftCorners[3], &r.LeftCorners[2])
-	cycleItems(prime, &r.LeftEdges[1], &r.LeftEdges[0], &r.LeftEdges[3], &r.LeftEdges[2])
 	turnEdge(&r.LeftEdges[0], EdgeTopRight, TurnOfRightLeft)
 	turnEdge(&r.LeftEdges[1], EdgeFrontRight, TurnOfRightLeft)
 	turnEdge(&r.LeftEdges[2], EdgeTopRight, TurnOfRightLeft)
 	turnEdge(&r.LeftEdges[3], EdgeFrontRight, TurnOfRightLeft)
+	cycleItems(prime, &r.LeftEdges[1], &r.LeftEdges[0], &r.LeftEdges[3], &r.LeftEdges[2])
 	return r
 }
 
+// cycleItems moves the item in a to b, b to c, c to d and d to a. The cycle
+// runs the other way around when reverse is true.
 func cycleItems[T comparable](reverse bool, a, b, c, d *T) {
 	if reverse {
 		z := *a
@@ -144,12 +307,14 @@ func cycleCorners(reverse bool, t TurnOfCubelet, a, b, c, d *CornerCubelet) {
 	*d = d.Turn(t)
 }
 
+// turnEdge updates the rotation of an edge currently in the position p for the
+// turn t, this must be called before the edge is moved out of the position.
 func turnEdge(edge *EdgeCubelet, p EdgePosition, t TurnOfCubelet) {
 	*edge = edge.Turn(p, t)
 }
 
-// Face returns the color of each cubelet on a specified face. face[4] will
-// always be equal to c.
+// Face returns the color of each cubelet on a specified face of the cube as it
+// is currently being held. face[4] is the color of the center on that face.
 //
 // The face will be returned as FaceData where indexes 0, 1, 2 is the first row
 // of the face following the rotation display in the diagram below.
@@ -164,68 +329,85 @@ func turnEdge(edge *EdgeCubelet, p EdgePosition, t TurnOfCubelet) {
 // . . . d d d . . . . . .
 // . . . d d d . . . . . .
 func (r RubiksCube) Face(f Face) (face FaceData) {
+	z := r.standardFace(r.Orientation.Face(f))
+	for i := range face {
+		face[i] = z[orientationFacelets[r.Orientation][f][i]]
+	}
+	return
+}
+
+// faces returns the colors of every face as the cube is held.
+func (r RubiksCube) faces() (z CubeFaceData) {
+	for f := FaceUp; f <= FaceLeft; f++ {
+		z[f] = r.Face(f)
+	}
+	return
+}
+
+// standardFace returns the colors of the face f in the standard orientation.
+func (r RubiksCube) standardFace(f Face) (face FaceData) {
 	face = FaceData{255, 255, 255, 255, 255, 255, 255, 255, 255}
 	switch f {
 	case FaceUp:
-		face[0] = r.LeftCorners[1].GetColor(FacingUpDown)
+		face[0] = r.LeftCorners[1].colorAt(CornerAntiClockwise, FacingUpDown)
 		face[1] = r.MiddleEdges[1].GetColor(EdgeTopFront, FacingUpDown)
-		face[2] = r.RightCorners[1].GetColor(FacingUpDown)
+		face[2] = r.RightCorners[1].colorAt(CornerClockwise, FacingUpDown)
 		face[3] = r.LeftEdges[0].GetColor(EdgeTopRight, FacingUpDown)
 		face[4] = White
 		face[5] = r.RightEdges[0].GetColor(EdgeTopRight, FacingUpDown)
-		face[6] = r.LeftCorners[0].GetColor(FacingUpDown)
+		face[6] = r.LeftCorners[0].colorAt(CornerClockwise, FacingUpDown)
 		face[7] = r.MiddleEdges[0].GetColor(EdgeTopFront, FacingUpDown)
-		face[8] = r.RightCorners[0].GetColor(FacingUpDown)
+		face[8] = r.RightCorners[0].colorAt(CornerAntiClockwise, FacingUpDown)
 	case FaceDown:
-		face[0] = r.LeftCorners[3].GetColor(FacingUpDown)
+		face[0] = r.LeftCorners[3].colorAt(CornerAntiClockwise, FacingUpDown)
 		face[1] = r.MiddleEdges[3].GetColor(EdgeTopFront, FacingUpDown)
-		face[2] = r.RightCorners[3].GetColor(FacingUpDown)
+		face[2] = r.RightCorners[3].colorAt(CornerClockwise, FacingUpDown)
 		face[3] = r.LeftEdges[2].GetColor(EdgeTopRight, FacingUpDown)
 		face[4] = Yellow
 		face[5] = r.RightEdges[2].GetColor(EdgeTopRight, FacingUpDown)
-		face[6] = r.LeftCorners[2].GetColor(FacingUpDown)
+		face[6] = r.LeftCorners[2].colorAt(CornerClockwise, FacingUpDown)
 		face[7] = r.MiddleEdges[2].GetColor(EdgeTopFront, FacingUpDown)
-		face[8] = r.RightCorners[2].GetColor(FacingUpDown)
+		face[8] = r.RightCorners[2].colorAt(CornerAntiClockwise, FacingUpDown)
 	case FaceFront:
-		face[0] = r.LeftCorners[0].GetColor(FacingFrontBack)
+		face[0] = r.LeftCorners[0].colorAt(CornerClockwise, FacingFrontBack)
 		face[1] = r.MiddleEdges[0].GetColor(EdgeTopFront, FacingFrontBack)
-		face[2] = r.RightCorners[0].GetColor(FacingFrontBack)
+		face[2] = r.RightCorners[0].colorAt(CornerAntiClockwise, FacingFrontBack)
 		face[3] = r.LeftEdges[3].GetColor(EdgeFrontRight, FacingFrontBack)
 		face[4] = Orange
 		face[5] = r.RightEdges[3].GetColor(EdgeFrontRight, FacingFrontBack)
-		face[6] = r.LeftCorners[3].GetColor(FacingFrontBack)
+		face[6] = r.LeftCorners[3].colorAt(CornerAntiClockwise, FacingFrontBack)
 		face[7] = r.MiddleEdges[3].GetColor(EdgeTopFront, FacingFrontBack)
-		face[8] = r.RightCorners[3].GetColor(FacingFrontBack)
+		face[8] = r.RightCorners[3].colorAt(CornerClockwise, FacingFrontBack)
 	case FaceBack:
-		face[0] = r.RightCorners[1].GetColor(FacingFrontBack)
+		face[0] = r.RightCorners[1].colorAt(CornerClockwise, FacingFrontBack)
 		face[1] = r.MiddleEdges[1].GetColor(EdgeTopFront, FacingFrontBack)
-		face[2] = r.LeftCorners[1].GetColor(FacingFrontBack)
+		face[2] = r.LeftCorners[1].colorAt(CornerAntiClockwise, FacingFrontBack)
 		face[3] = r.RightEdges[1].GetColor(EdgeFrontRight, FacingFrontBack)
 		face[4] = Red
 		face[5] = r.LeftEdges[1].GetColor(EdgeFrontRight, FacingFrontBack)
-		face[6] = r.RightCorners[2].GetColor(FacingFrontBack)
+		face[6] = r.RightCorners[2].colorAt(CornerAntiClockwise, FacingFrontBack)
 		face[7] = r.MiddleEdges[2].GetColor(EdgeTopFront, FacingFrontBack)
-		face[8] = r.LeftCorners[2].GetColor(FacingFrontBack)
+		face[8] = r.LeftCorners[2].colorAt(CornerClockwise, FacingFrontBack)
 	case FaceRight:
-		face[0] = r.RightCorners[0].GetColor(FacingRightLeft)
+		face[0] = r.RightCorners[0].colorAt(CornerAntiClockwise, FacingRightLeft)
 		face[1] = r.RightEdges[0].GetColor(EdgeTopRight, FacingRightLeft)
-		face[2] = r.RightCorners[1].GetColor(FacingRightLeft)
+		face[2] = r.RightCorners[1].colorAt(CornerClockwise, FacingRightLeft)
 		face[3] = r.RightEdges[3].GetColor(EdgeFrontRight, FacingRightLeft)
 		face[4] = Green
 		face[5] = r.RightEdges[1].GetColor(EdgeFrontRight, FacingRightLeft)
-		face[6] = r.RightCorners[3].GetColor(FacingRightLeft)
+		face[6] = r.RightCorners[3].colorAt(CornerClockwise, FacingRightLeft)
 		face[7] = r.RightEdges[2].GetColor(EdgeTopRight, FacingRightLeft)
-		face[8] = r.RightCorners[2].GetColor(FacingRightLeft)
+		face[8] = r.RightCorners[2].colorAt(CornerAntiClockwise, FacingRightLeft)
 	case FaceLeft:
-		face[0] = r.LeftCorners[1].GetColor(FacingRightLeft)
-		face[1] = r.LeftEdges[3].GetColor(EdgeFrontRight, FacingRightLeft)
-		face[2] = r.LeftCorners[0].GetColor(FacingRightLeft)
-		face[3] = r.LeftEdges[0].GetColor(EdgeTopRight, FacingRightLeft)
+		face[0] = r.LeftCorners[1].colorAt(CornerAntiClockwise, FacingRightLeft)
+		face[1] = r.LeftEdges[0].GetColor(EdgeTopRight, FacingRightLeft)
+		face[2] = r.LeftCorners[0].colorAt(CornerClockwise, FacingRightLeft)
+		face[3] = r.LeftEdges[1].GetColor(EdgeFrontRight, FacingRightLeft)
 		face[4] = Blue
-		face[5] = r.LeftEdges[2].GetColor(EdgeTopRight, FacingRightLeft)
-		face[6] = r.LeftCorners[2].GetColor(FacingRightLeft)
-		face[7] = r.LeftEdges[1].GetColor(EdgeFrontRight, FacingRightLeft)
-		face[8] = r.LeftCorners[3].GetColor(FacingRightLeft)
+		face[5] = r.LeftEdges[3].GetColor(EdgeFrontRight, FacingRightLeft)
+		face[6] = r.LeftCorners[2].colorAt(CornerClockwise, FacingRightLeft)
+		face[7] = r.LeftEdges[2].GetColor(EdgeTopRight, FacingRightLeft)
+		face[8] = r.LeftCorners[3].colorAt(CornerAntiClockwise, FacingRightLeft)
 	}
 
 	return
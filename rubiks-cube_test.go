@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"embed"
 	"errors"
-	"fmt"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"io/fs"
@@ -30,36 +29,46 @@ func TestRubiksCube(t *testing.T) {
 	}
 }
 
-func runTests(t *testing.T, r io.Reader) {
-	rd := bufio.NewReader(r)
+func runTests(t *testing.T, r *bufio.Reader) {
+	cube, err := readTestCube(r)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil || !bytes.HasPrefix(line, []byte("# ")) {
+			return
+		}
+		moves := bytes.TrimSpace(line[2:])
+		scanner := NewMoveScanner(bytes.NewReader(moves))
+		for scanner.Scan() {
+			cube = cube.Move(scanner.Current())
+		}
+		if !assert.NoError(t, scanner.Err()) {
+			return
+		}
+		expected, err := readTestCube(r)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, expected.String(), cube.String(), "after moves: %s", moves)
+	}
+}
+
+func readTestCube(r *bufio.Reader) (RubiksCube, error) {
 	var s strings.Builder
 	s.Grow(13 * 9)
 	for i := 0; i < 9; i++ {
-		line, err := rd.ReadBytes('\n')
+		line, err := r.ReadBytes('\n')
 		if err != nil {
-			return
+			return RubiksCube{}, err
 		}
 		s.Write(line)
 	}
-	cube, err := ParseCube(s.String())
-	if err != nil {
-		t.Error(err)
-	}
-	line, err := rd.ReadBytes('\n')
-	if err != nil {
-		return
-	}
-	if line[0] == '#' && line[1] == ' ' {
-		scanner := NewMoveScanner(bytes.NewReader(line[2:]))
-		for scanner.Scan() {
-			fmt.Println(scanner.Current())
-			cube = cube.Move(scanner.Current())
-		}
-		fmt.Println(cube)
-	}
+	return ParseCube(s.String())
 }
 
-func startScanningText(t *testing.T, open io.Reader, cb func(t *testing.T, r io.Reader)) error {
+func startScanningText(t *testing.T, open io.Reader, cb func(t *testing.T, r *bufio.Reader)) error {
 	rd := bufio.NewReader(open)
 	for {
 		b, err := rd.ReadBytes('\n')
@@ -96,3 +105,10 @@ func listFiles(start string, files chan<- string) {
 	}
 	close(files)
 }
+
+func TestRubiksCube_Hold(t *testing.T) {
+	cube := NewSolvedCube().Move(Right).Hold(NewOrientation(FaceFront, FaceDown))
+	assert.Equal(t, NewSolvedCube().Move(Right).Move(RotationX).String(), cube.String())
+	assert.Equal(t, Orange, cube.Face(FaceUp)[4])
+	assert.Equal(t, NewSolvedCube().Move(Right), cube.Hold(StandardOrientation))
+}
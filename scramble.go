@@ -0,0 +1,126 @@
+package rubiks_cube
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
+
+// DefaultMinDistance is the fewest moves which may solve a state picked by
+// Scramble, as the WCA regulations do not allow a scramble to be solved in
+// fewer than two moves.
+const DefaultMinDistance = 2
+
+// ScrambleOptions changes how Scramble makes a scramble.
+type ScrambleOptions struct {
+	// Source picks the random state. Nil reads from crypto/rand, which
+	// should be used for competitions. A seeded source makes the same
+	// scrambles each time.
+	Source rand.Source
+
+	// MinDistance is the fewest moves which may solve the state. States
+	// closer to solved are thrown away and another is picked. Zero uses
+	// DefaultMinDistance. Checking each extra move takes about 13 times
+	// longer.
+	MinDistance int
+
+	// Tables are the tables used to solve the state. Nil uses DefaultTables.
+	Tables *Tables
+}
+
+// Scramble picks a random state with RandomCube and returns the inverse of its
+// solution from Solve, which turns a solved cube into the state. The scramble
+// only uses face moves and never turns the same face twice in a row.
+func Scramble(opts ScrambleOptions) (Algorithm, error) {
+	if opts.Source == nil {
+		opts.Source = cryptoSource{}
+	}
+	if opts.MinDistance <= 0 {
+		opts.MinDistance = DefaultMinDistance
+	}
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	for {
+		cube := RandomCube(opts.Source)
+		if solvableWithin(moves, cube.CubieCube(), opts.MinDistance-1, Move(faceMoveCount)) {
+			continue
+		}
+		solution, err := Solve(cube, SolveOptions{Tables: opts.Tables})
+		if err != nil {
+			return nil, err
+		}
+		return solution.Inverse().Simplify(), nil
+	}
+}
+
+// RandomCube returns a state picked uniformly from every solvable state of the
+// cube, held in the standard orientation.
+//
+// The permutation and orientation of the corners and edges are each picked at
+// random. The twist of the last corner and the flip of the last edge follow
+// from the others, and when the permutations of the corners and edges have
+// different parity the last two edges are swapped, which pairs every
+// unsolvable permutation with exactly one solvable permutation.
+func RandomCube(src rand.Source) RubiksCube {
+	r := rand.New(src)
+	c := NewSolvedCubieCube()
+	c.SetCornerPermutation(r.Intn(CornerPermutationCount))
+	c.SetEdgePermutation(r.Intn(EdgePermutationCount))
+	c.SetTwist(r.Intn(TwistCount))
+	c.SetFlip(r.Intn(FlipCount))
+	if oddBytePermutation(c.CP[:]) != oddBytePermutation(c.EP[:]) {
+		c.EP[10], c.EP[11] = c.EP[11], c.EP[10]
+	}
+	return c.RubiksCube()
+}
+
+// oddBytePermutation returns true if the permutation p is made from an odd
+// number of swaps, see oddPermutation.
+func oddBytePermutation(p []byte) bool {
+	q := make([]int, len(p))
+	for i, j := range p {
+		q[i] = int(j)
+	}
+	return oddPermutation(q)
+}
+
+// solvableWithin returns true if at most n face moves after the move last
+// solve the cube.
+func solvableWithin(moves [faceMoveCount]CubieCube, c CubieCube, n int, last Move) bool {
+	if c.IsSolved() {
+		return true
+	}
+	if n <= 0 {
+		return false
+	}
+	for m := Move(0); m < faceMoveCount; m++ {
+		if last < faceMoveCount && !canFollow(last, m) {
+			continue
+		}
+		if solvableWithin(moves, c.Multiply(moves[m]), n-1, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// cryptoSource is a rand.Source reading from crypto/rand, which cannot be
+// seeded.
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() >> 1)
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		// crypto/rand only fails if the system cannot give random bytes
+		panic(err)
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+func (cryptoSource) Seed(int64) {}
@@ -0,0 +1,78 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// zeroSource returns zero n times before reading from the source.
+type zeroSource struct {
+	rand.Source
+	n int
+}
+
+func (s *zeroSource) Int63() int64 {
+	if s.n > 0 {
+		s.n--
+		return 0
+	}
+	return s.Source.Int63()
+}
+
+func TestScramble(t *testing.T) {
+	for i := int64(0); i < 10; i++ {
+		scramble, err := Scramble(ScrambleOptions{Source: rand.NewSource(i)})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, scramble.HTM(), DefaultMaxLength)
+		for j, m := range scramble {
+			assert.False(t, m.Rotation() || m.Slice() || m.Wide(), scramble.String())
+			if j > 0 {
+				assert.NotEqual(t, scramble[j-1]%6, m%6, scramble.String())
+			}
+		}
+
+		// the scramble makes the random state of the source
+		cube := NewSolvedCube().Apply(scramble)
+		assert.Equal(t, RandomCube(rand.NewSource(i)), cube)
+		again, err := Scramble(ScrambleOptions{Source: rand.NewSource(i)})
+		assert.NoError(t, err)
+		assert.Equal(t, scramble, again)
+	}
+
+	// a solved state is thrown away and the next state is used
+	scramble, err := Scramble(ScrambleOptions{Source: &zeroSource{rand.NewSource(1), 4}})
+	assert.NoError(t, err)
+	assert.False(t, NewSolvedCube().Apply(scramble).IsSolved())
+	assert.Equal(t, NewSolvedCube(), RandomCube(&zeroSource{rand.NewSource(1), 4}))
+
+	scramble, err = Scramble(ScrambleOptions{})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, scramble.HTM(), DefaultMinDistance)
+}
+
+func TestRandomCube(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var parity [2]int
+	for i := 0; i < 1000; i++ {
+		cube := RandomCube(r)
+		assert.NoError(t, cube.Validate())
+		assert.Equal(t, StandardOrientation, cube.Orientation)
+		if cube.Parity() {
+			parity[1]++
+		} else {
+			parity[0]++
+		}
+	}
+	assert.InDelta(t, 500, parity[0], 100)
+}
+
+func TestSolvableWithin(t *testing.T) {
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	c := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U2 F'")).CubieCube()
+	assert.False(t, solvableWithin(moves, c, 2, Move(faceMoveCount)))
+	assert.True(t, solvableWithin(moves, c, 3, Move(faceMoveCount)))
+}
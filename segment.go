@@ -0,0 +1,51 @@
+package rubiks_cube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment is the part of a solution made by one stage of a solver.
+type Segment struct {
+	// Stage is the short name of the stage.
+	Stage string
+
+	// Description explains what the stage does for the cube.
+	Description string
+
+	// Algorithm is the moves made during the stage, which may be empty if
+	// the stage was already done.
+	Algorithm Algorithm
+}
+
+// Solution is a solution split into the stages of the solver which found it.
+type Solution []Segment
+
+// Algorithm joins the algorithms of every segment into one algorithm.
+func (s Solution) Algorithm() Algorithm {
+	parts := make([]Algorithm, len(s))
+	for i, seg := range s {
+		parts[i] = seg.Algorithm
+	}
+	return Algorithm{}.Concat(parts...)
+}
+
+// String writes each segment on its own line followed by a comment naming the
+// stage and counting its moves in STM, which can be read back with
+// ParseAlgorithm.
+func (s Solution) String() string {
+	var b strings.Builder
+	for i, seg := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if len(seg.Algorithm) > 0 {
+			b.WriteString(seg.Algorithm.String())
+			b.WriteByte(' ')
+		}
+		b.WriteString("// ")
+		b.WriteString(seg.Stage)
+		fmt.Fprintf(&b, " (%d)", seg.Algorithm.STM())
+	}
+	return b.String()
+}
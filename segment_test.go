@@ -0,0 +1,21 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSolution(t *testing.T) {
+	s := Solution{
+		{Stage: "Cross", Algorithm: mustParseAlgorithm(t, "F R")},
+		{Stage: "Skip", Algorithm: Algorithm{}},
+		{Stage: "Last", Algorithm: mustParseAlgorithm(t, "U2")},
+	}
+	assert.Equal(t, mustParseAlgorithm(t, "F R U2"), s.Algorithm())
+	assert.Equal(t, "F R // Cross (2)\n// Skip (0)\nU2 // Last (1)", s.String())
+
+	a, err := ParseAlgorithm(s.String())
+	assert.NoError(t, err)
+	assert.Equal(t, s.Algorithm(), a)
+	assert.Equal(t, Algorithm{}, Solution{}.Algorithm())
+}
@@ -0,0 +1,98 @@
+package rubiks_cube
+
+// Simplify returns an equivalent algorithm with the moves merged and
+// cancelled. Moves around the same axis can be turned in any order, so each
+// run of these moves is merged into at most one move for each layer and then
+// sorted into the order of the Move values, for example "R L R" becomes
+// "R2 L" and "L U U' R" becomes "R L". Invalid moves are removed.
+//
+// The cube after applying the simplified algorithm is always the same as the
+// cube after applying a.
+func (a Algorithm) Simplify() Algorithm {
+	var groups []simplifyGroup
+	for _, m := range a {
+		if !m.Valid() {
+			continue
+		}
+		if len(groups) == 0 || groups[len(groups)-1].axis != m.Axis() {
+			groups = append(groups, simplifyGroup{axis: m.Axis()})
+		}
+		g := &groups[len(groups)-1]
+		g.add(m)
+		if g.empty() {
+			groups = groups[:len(groups)-1]
+		}
+	}
+
+	z := make(Algorithm, 0, len(a))
+	for _, g := range groups {
+		z = g.appendMoves(z)
+	}
+	return z
+}
+
+// SimplifyAcrossRotations returns an equivalent algorithm where the whole cube
+// rotations have been removed and the moves after them changed to turn the
+// same layers without the rotation. This allows moves on either side of a
+// rotation to cancel, for example "R x U x' R'" becomes "R F R'". The
+// shortest rotations to leave the cube in the same orientation are added to the
+// end of the algorithm before it is simplified.
+func (a Algorithm) SimplifyAcrossRotations() Algorithm {
+	o := StandardOrientation
+	z := make(Algorithm, 0, len(a)+2)
+	for _, m := range a {
+		switch {
+		case !m.Valid():
+		case m.Rotation():
+			o = o.Rotate(m)
+		default:
+			z = append(z, o.translate(m))
+		}
+	}
+	return append(z, o.Rotations()...).Simplify()
+}
+
+// simplifyGroup holds the total amount each layer has been turned by a run of
+// moves around the same axis.
+type simplifyGroup struct {
+	axis    TurnOfCubelet
+	amounts [LeftWide + 1]byte
+}
+
+// add turns the layers of m by the amount of m, where each amount is counted
+// in clockwise quarter turns.
+func (g *simplifyGroup) add(m Move) {
+	n := byte(1)
+	switch {
+	case m.Prime():
+		n = 3
+	case m.Double():
+		n = 2
+	}
+	q := m.Quarter()
+	g.amounts[q] = (g.amounts[q] + n) % 4
+}
+
+func (g *simplifyGroup) empty() bool {
+	for _, i := range g.amounts {
+		if i != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *simplifyGroup) appendMoves(z Algorithm) Algorithm {
+	for i, n := range g.amounts {
+		m := Move(i)
+		switch n {
+		case 1:
+			z = append(z, m)
+		case 2:
+			z = append(z, m.Twice())
+		case 3:
+			z = append(z, m.Reverse())
+		}
+	}
+	return z
+}
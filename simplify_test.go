@@ -0,0 +1,88 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestAlgorithm_Simplify(t *testing.T) {
+	for _, i := range [][2]string{
+		{"R R'", ""},
+		{"U U U", "U'"},
+		{"U U U U", ""},
+		{"R L R", "R2 L"},
+		{"L R", "R L"},
+		{"L U U' R", "R L"},
+		{"R U R' R U' R'", ""},
+		{"D U2 D'", "U2"},
+		{"R2 M' L x r' R2", "L M' x Rw'"},
+		{"F B F' B' S", "S"},
+		{"R x U x' R'", "R x U R' x'"},
+	} {
+		a := mustParseAlgorithm(t, i[0])
+		assert.Equal(t, i[1], a.Simplify().String(), i[0])
+	}
+}
+
+func TestAlgorithm_SimplifyAcrossRotations(t *testing.T) {
+	for _, i := range [][2]string{
+		{"R x U x' R'", "R F R'"},
+		{"x y x'", "z"},
+		{"y R y'", "B"},
+		{"z M z'", "E"},
+		{"x2 U x2 D", "D2"},
+		{"y r U y'", "Bw U"},
+		{"y2 x", "x z2"},
+	} {
+		a := mustParseAlgorithm(t, i[0])
+		assert.Equal(t, i[1], a.SimplifyAcrossRotations().String(), i[0])
+	}
+}
+
+func randomAlgorithm(r *rand.Rand, moves Algorithm, n int) Algorithm {
+	a := make(Algorithm, n)
+	for i := range a {
+		a[i] = moves[r.Intn(len(moves))]
+	}
+	return a
+}
+
+func TestAlgorithm_Simplify_Property(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= LeftWide2; m++ {
+		all = append(all, m)
+	}
+	// a small set of moves creates many more cancellations
+	few := Algorithm{Right, RightPrime, Left2, Up, Down, Middle, RotationX, RotationY, RightWide, UpWidePrime}
+
+	for i := 0; i < 2000; i++ {
+		moves := all
+		if i%2 == 0 {
+			moves = few
+		}
+		a := randomAlgorithm(r, moves, r.Intn(30))
+		cube := NewSolvedCube().Apply(a)
+
+		b := a.Simplify()
+		assert.Equal(t, cube, NewSolvedCube().Apply(b), a.String())
+		assert.LessOrEqual(t, b.ETM(), a.ETM(), a.String())
+		assert.Equal(t, b, b.Simplify(), a.String())
+
+		c := a.SimplifyAcrossRotations()
+		assert.Equal(t, cube, NewSolvedCube().Apply(c), a.String())
+		assert.LessOrEqual(t, c.ETM(), a.ETM(), a.String())
+		assert.LessOrEqual(t, c.STM(), b.STM(), a.String())
+		assert.Equal(t, c, c.SimplifyAcrossRotations(), a.String())
+	}
+}
+
+func TestOrientation_Rotations(t *testing.T) {
+	for i := range orientationTable {
+		o := Orientation(i)
+		rotations := o.Rotations()
+		assert.LessOrEqual(t, len(rotations), 2)
+		assert.Equal(t, o, NewSolvedCube().Apply(rotations).Orientation)
+	}
+}
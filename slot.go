@@ -0,0 +1,155 @@
+package rubiks_cube
+
+// CornerSlot is the position of a corner named by the faces of the standard
+// orientation. The values are in the order of RightCorners followed by
+// LeftCorners.
+type CornerSlot byte
+
+const (
+	SlotUFR CornerSlot = iota
+	SlotUBR
+	SlotDBR
+	SlotDFR
+	SlotUFL
+	SlotUBL
+	SlotDBL
+	SlotDFL
+)
+
+var cornerSlotNameTable = [8]string{"UFR", "UBR", "DBR", "DFR", "UFL", "UBL", "DBL", "DFL"}
+
+func (s CornerSlot) Valid() bool {
+	return s <= SlotDFL
+}
+
+// Position returns the handedness of the corner slot.
+func (s CornerSlot) Position() CornerPosition {
+	return cornerSlotPositionTable[s]
+}
+
+func (s CornerSlot) String() string {
+	if !s.Valid() {
+		return "CornerSlot(?)"
+	}
+	return cornerSlotNameTable[s]
+}
+
+// EdgeSlot is the position of an edge named by the faces of the standard
+// orientation. The values are in the order of RightEdges, LeftEdges and then
+// MiddleEdges.
+type EdgeSlot byte
+
+const (
+	SlotUR EdgeSlot = iota
+	SlotBR
+	SlotDR
+	SlotFR
+	SlotUL
+	SlotBL
+	SlotDL
+	SlotFL
+	SlotUF
+	SlotUB
+	SlotDB
+	SlotDF
+)
+
+var edgeSlotNameTable = [12]string{"UR", "BR", "DR", "FR", "UL", "BL", "DL", "FL", "UF", "UB", "DB", "DF"}
+
+func (s EdgeSlot) Valid() bool {
+	return s <= SlotDF
+}
+
+// Position returns the position type of the edge slot used to turn an
+// EdgeCubelet.
+func (s EdgeSlot) Position() EdgePosition {
+	return edgeSlotPositionTable[s]
+}
+
+func (s EdgeSlot) String() string {
+	if !s.Valid() {
+		return "EdgeSlot(?)"
+	}
+	return edgeSlotNameTable[s]
+}
+
+// cornerRef and edgeRef return a pointer to the cubelet stored for a slot.
+func (r *RubiksCube) cornerRef(s CornerSlot) *CornerCubelet {
+	if s < SlotUFL {
+		return &r.RightCorners[s]
+	}
+	return &r.LeftCorners[s-SlotUFL]
+}
+
+func (r *RubiksCube) edgeRef(s EdgeSlot) *EdgeCubelet {
+	switch {
+	case s < SlotUL:
+		return &r.RightEdges[s]
+	case s < SlotUF:
+		return &r.LeftEdges[s-SlotUL]
+	}
+	return &r.MiddleEdges[s-SlotUF]
+}
+
+// CornerAt returns the corner cubelet in the slot s, or false if the slot is
+// not valid.
+func (r RubiksCube) CornerAt(s CornerSlot) (CornerCubelet, bool) {
+	if !s.Valid() {
+		return 0, false
+	}
+	return *r.cornerRef(s), true
+}
+
+// EdgeAt returns the edge cubelet in the slot s, or false if the slot is not
+// valid.
+func (r RubiksCube) EdgeAt(s EdgeSlot) (EdgeCubelet, bool) {
+	if !s.Valid() {
+		return 0, false
+	}
+	return *r.edgeRef(s), true
+}
+
+// SetCorner puts the corner cubelet c in the slot s, or returns false without
+// changing the cube if the slot is not valid. The cube is not checked so it
+// can be made unsolvable, see RubiksCube.Validate.
+func (r *RubiksCube) SetCorner(s CornerSlot, c CornerCubelet) bool {
+	if !s.Valid() {
+		return false
+	}
+	*r.cornerRef(s) = c
+	return true
+}
+
+// SetEdge puts the edge cubelet e in the slot s, or returns false without
+// changing the cube if the slot is not valid. The cube is not checked so it
+// can be made unsolvable, see RubiksCube.Validate.
+func (r *RubiksCube) SetEdge(s EdgeSlot, e EdgeCubelet) bool {
+	if !s.Valid() {
+		return false
+	}
+	*r.edgeRef(s) = e
+	return true
+}
+
+// WhereIsCorner finds the slot holding the corner t and the number of times
+// it is twisted clockwise, see CornerCubelet.Twist. The invalid slot 255 is
+// returned if the corner is missing.
+func (r RubiksCube) WhereIsCorner(t CornerType) (CornerSlot, byte) {
+	for s := SlotUFR; s <= SlotDFL; s++ {
+		if c := *r.cornerRef(s); c.Piece() == t {
+			return s, c.Twist(s.Position())
+		}
+	}
+	return 255, 0
+}
+
+// WhereIsEdge finds the slot holding the edge t and whether it is flipped.
+// The invalid slot 255 is returned if the edge is missing.
+func (r RubiksCube) WhereIsEdge(t EdgeType) (EdgeSlot, EdgeFacing) {
+	for s := SlotUR; s <= SlotDF; s++ {
+		if e := *r.edgeRef(s); e.Piece() == t {
+			return s, e.Rotation()
+		}
+	}
+	return 255, 0
+}
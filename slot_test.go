@@ -0,0 +1,81 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRubiksCube_CornerAt(t *testing.T) {
+	cube := NewSolvedCube()
+	c, ok := cube.CornerAt(SlotDBR)
+	assert.True(t, ok)
+	assert.Equal(t, cube.RightCorners[2], c)
+	c, _ = cube.CornerAt(SlotUBL)
+	assert.Equal(t, cube.LeftCorners[1], c)
+	e, ok := cube.EdgeAt(SlotUB)
+	assert.True(t, ok)
+	assert.Equal(t, cube.MiddleEdges[1], e)
+	e, _ = cube.EdgeAt(SlotFL)
+	assert.Equal(t, cube.LeftEdges[3], e)
+
+	_, ok = cube.CornerAt(SlotDFL + 1)
+	assert.False(t, ok)
+	_, ok = cube.EdgeAt(SlotDF + 1)
+	assert.False(t, ok)
+
+	cube = cube.Move(Up)
+	c, _ = cube.CornerAt(SlotUFL)
+	assert.Equal(t, CornerWhiteOrangeGreen, c.Piece())
+	e, _ = cube.EdgeAt(SlotUL)
+	assert.Equal(t, EdgeWhiteOrange, e.Piece())
+
+	slot, twist := cube.WhereIsCorner(CornerWhiteOrangeGreen)
+	assert.Equal(t, SlotUFL, slot)
+	assert.Equal(t, byte(0), twist)
+	edge, flip := cube.WhereIsEdge(EdgeWhiteOrange)
+	assert.Equal(t, SlotUL, edge)
+	assert.Equal(t, EdgeNormal, flip)
+
+	cube = NewSolvedCube().Move(Right)
+	slot, twist = cube.WhereIsCorner(CornerWhiteOrangeGreen)
+	assert.Equal(t, SlotUBR, slot)
+	assert.Equal(t, byte(1), twist)
+
+	cube = NewSolvedCube().Move(Front)
+	edge, flip = cube.WhereIsEdge(EdgeWhiteOrange)
+	assert.Equal(t, SlotFR, edge)
+	assert.Equal(t, EdgeOpposite, flip)
+}
+
+func TestRubiksCube_SetCorner(t *testing.T) {
+	cube := NewSolvedCube()
+	a, _ := cube.CornerAt(SlotUFR)
+	b, _ := cube.CornerAt(SlotUBL)
+	assert.True(t, cube.SetCorner(SlotUFR, b))
+	assert.True(t, cube.SetCorner(SlotUBL, a))
+	assert.Equal(t, b, cube.RightCorners[0])
+	assert.Equal(t, a, cube.LeftCorners[1])
+
+	e, _ := cube.EdgeAt(SlotDB)
+	f, _ := cube.EdgeAt(SlotBL)
+	assert.True(t, cube.SetEdge(SlotDB, f))
+	assert.True(t, cube.SetEdge(SlotBL, e))
+	assert.Equal(t, f, cube.MiddleEdges[2])
+	assert.Equal(t, e, cube.LeftEdges[1])
+	assert.NoError(t, cube.Validate())
+
+	// invalid slots leave the cube unchanged
+	before := cube
+	assert.False(t, cube.SetCorner(SlotDFL+1, a))
+	assert.False(t, cube.SetEdge(255, e))
+	assert.Equal(t, before, cube)
+}
+
+func TestSlot_String(t *testing.T) {
+	assert.Equal(t, "DFL", SlotDFL.String())
+	assert.Equal(t, "BR", SlotBR.String())
+	for s := SlotUR; s <= SlotDF; s++ {
+		f := edgeFaceletTable[s]
+		assert.Equal(t, faceNotationTable[f[0].Face]+faceNotationTable[f[1].Face], s.String())
+	}
+}
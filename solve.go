@@ -0,0 +1,310 @@
+package rubiks_cube
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultMaxLength is the length of solution Solve searches for when
+// SolveOptions.MaxLength is zero.
+const DefaultMaxLength = 20
+
+// DefaultTimeout is how long Solve searches when SolveOptions.Timeout is zero.
+// A solution of DefaultMaxLength moves is usually found well within a second,
+// but a shorter MaxLength may never be reached.
+const DefaultTimeout = 10 * time.Second
+
+// The longest phase 1 searched by the two phase solver and the longest phase 2
+// needed to solve any cube in phase 2.
+const (
+	maxPhase1Length = 30
+	maxPhase2Length = 18
+)
+
+var ErrSolveTimeout = errors.New("no solution found before the timeout")
+
+// SolveOptions changes how Solve searches for a solution.
+type SolveOptions struct {
+	// MaxLength stops the search once a solution with at most this many
+	// moves is found. Zero uses DefaultMaxLength.
+	MaxLength int
+
+	// Timeout stops the search and returns the shortest solution found so
+	// far. Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	// Progress is called with each solution found, which are each shorter
+	// than the last.
+	Progress func(Algorithm)
+
+	// Tables are the tables used by the search. Nil uses DefaultTables.
+	Tables *Tables
+}
+
+// Solve finds an algorithm which solves the cube with Kociemba's two phase
+// algorithm. The first phase moves the cube into the group generated by
+// U, D, R2, L2, F2 and B2, then the second phase solves the cube using only
+// those moves. Longer first phases are tried until a solution is short enough.
+//
+// The algorithm only uses face moves and turns the faces as the cube is held.
+// The cube is checked with RubiksCube.Validate first.
+func Solve(cube RubiksCube, opts SolveOptions) (Algorithm, error) {
+	if err := cube.Validate(); err != nil {
+		return nil, err
+	}
+	s := &twoPhaseSearch{
+		tables:    opts.Tables,
+		maxLength: opts.MaxLength,
+		progress:  opts.Progress,
+	}
+	if s.tables == nil {
+		s.tables = DefaultTables()
+	}
+	if s.maxLength <= 0 {
+		s.maxLength = DefaultMaxLength
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	s.deadline = time.Now().Add(opts.Timeout)
+	for m := range s.moves {
+		s.moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	if s.progress != nil {
+		// the solutions passed to progress are for the cube as it is held
+		progress := s.progress
+		s.progress = func(a Algorithm) {
+			progress(holdAlgorithm(cube.Orientation, a))
+		}
+	}
+
+	s.run(cube.CubieCube())
+	if s.best == nil {
+		return nil, ErrSolveTimeout
+	}
+	return holdAlgorithm(cube.Orientation, s.best), nil
+}
+
+// holdAlgorithm changes the moves of an algorithm for a cube in the standard
+// orientation into the moves turning the same layers of a cube held in
+// orientation o.
+func holdAlgorithm(o Orientation, a Algorithm) Algorithm {
+	z := make(Algorithm, len(a))
+	for i, m := range a {
+		z[i] = o.untranslate(m)
+	}
+	return z
+}
+
+// twoPhaseSearch holds the state of a search by Solve. Moves are found using
+// the coordinates of the cube and the path holds the moves made so far.
+type twoPhaseSearch struct {
+	tables    *Tables
+	moves     [faceMoveCount]CubieCube
+	maxLength int
+	deadline  time.Time
+	progress  func(Algorithm)
+	best      Algorithm
+	nodes     int
+	done      bool
+
+	// the variant of the cube currently being searched
+	variant twoPhaseVariant
+	path    [maxPhase1Length + maxPhase2Length]Move
+}
+
+// twoPhaseVariant is the cube turned onto another axis or inverted. Some
+// variants have much shorter first phases, so searching all of them at once
+// finds short solutions sooner. Solution changes a solution of the variant
+// into a solution of the original cube.
+type twoPhaseVariant struct {
+	start    CubieCube
+	solution func(Algorithm) Algorithm
+}
+
+// axisCount is the number of axes of the cube.
+const axisCount = 3
+
+// axisOrientations returns the orientations which hold each axis of the cube
+// up and down, starting with the standard orientation.
+func axisOrientations() [axisCount]Orientation {
+	return [axisCount]Orientation{StandardOrientation, NewOrientation(FaceFront, FaceDown), NewOrientation(FaceRight, FaceFront)}
+}
+
+// twoPhaseVariants returns the cube c and its inverse, both held with each
+// axis up and down.
+func twoPhaseVariants(c CubieCube) []twoPhaseVariant {
+	var variants []twoPhaseVariant
+	for _, inverse := range []bool{false, true} {
+		start := c
+		if inverse {
+			start = c.Inverse()
+		}
+		for _, o := range axisOrientations() {
+			// solving the cube while holding it in o is the same as solving
+			// the cube turned by the inverse of o
+			rot := orientationCubies[o]
+			o, inverse := o, inverse
+			variants = append(variants, twoPhaseVariant{
+				start: rot.Inverse().Multiply(start).Multiply(rot),
+				solution: func(a Algorithm) Algorithm {
+					z := make(Algorithm, len(a))
+					for i, m := range a {
+						z[i] = o.translate(m)
+					}
+					if inverse {
+						z = z.Inverse()
+					}
+					return z
+				},
+			})
+		}
+	}
+	return variants
+}
+
+func (s *twoPhaseSearch) run(c CubieCube) {
+	variants := twoPhaseVariants(c)
+	for depth := 0; depth <= maxPhase1Length && !s.done; depth++ {
+		if s.best != nil && depth >= len(s.best) {
+			break
+		}
+		for _, v := range variants {
+			s.variant = v
+			s.phase1(uint16(v.start.Twist()), uint16(v.start.Flip()), uint16(v.start.SliceSorted()), uint16(v.start.CornerPermutation()), 0, depth)
+			if s.done {
+				break
+			}
+		}
+	}
+}
+
+// stop returns true once the search is finished or out of time. The time is
+// only checked every few thousand nodes.
+func (s *twoPhaseSearch) stop() bool {
+	s.nodes++
+	if !s.done && s.nodes%4096 == 0 && time.Now().After(s.deadline) {
+		s.done = true
+	}
+	return s.done
+}
+
+// phase1 searches for every sequence of exactly togo moves which moves the
+// cube into phase 2. The corners are followed as well so phase 2 is only
+// started when the corners can be solved soon enough.
+func (s *twoPhaseSearch) phase1(twist, flip, slice, corners uint16, n, togo int) {
+	if s.stop() {
+		return
+	}
+	t := s.tables
+	if togo == 0 {
+		// a phase 1 ending with a phase 2 move is found again as a shorter
+		// phase 1
+		if twist == 0 && flip == 0 && slice < sliceEdgePermutationSize && (n == 0 || !isPhase2Move(s.path[n-1])) &&
+			int(t.CornerSlicePrune[int(corners)*sliceEdgePermutationSize+int(slice)]) <= s.phase2Limit(n) {
+			s.startPhase2(n, corners)
+		}
+		return
+	}
+	for m := Move(0); m < faceMoveCount; m++ {
+		if n > 0 && !canFollow(s.path[n-1], m) {
+			continue
+		}
+		twist2, flip2, slice2 := t.TwistMove[twist][m], t.FlipMove[flip][m], t.SliceSortedMove[slice][m]
+		if int(t.FlipSliceTwistPrune.Get(t.flipSlice.index(twist2, flip2, int(slice2)/sliceEdgePermutationSize))) >= togo {
+			continue
+		}
+		s.path[n] = m
+		s.phase1(twist2, flip2, slice2, t.CornerPermutationMove[corners][m], n+1, togo-1)
+		if s.done {
+			return
+		}
+	}
+}
+
+// phase2Limit returns the longest phase 2 after the n moves of phase 1 which
+// makes a shorter solution than the best found so far.
+func (s *twoPhaseSearch) phase2Limit(n int) int {
+	if s.best != nil && len(s.best)-1-n < maxPhase2Length {
+		return len(s.best) - 1 - n
+	}
+	return maxPhase2Length
+}
+
+// startPhase2 searches for the shortest phase 2 after the n moves of phase 1
+// which makes a shorter solution than the best found so far.
+func (s *twoPhaseSearch) startPhase2(n int, corners uint16) {
+	c := s.variant.start
+	for _, m := range s.path[:n] {
+		c = c.Multiply(s.moves[m])
+	}
+	edges, slice := uint16(c.UDEdgePermutation()), uint16(c.SliceSorted())
+
+	limit := s.phase2Limit(n)
+	for depth := s.phase2Distance(corners, edges, slice); depth <= limit; depth++ {
+		if s.phase2(corners, edges, slice, n, depth) {
+			s.best = s.variant.solution(s.path[:n+depth])
+			if s.progress != nil {
+				s.progress(s.best)
+			}
+			if len(s.best) <= s.maxLength {
+				s.done = true
+			}
+			return
+		}
+		if s.done {
+			return
+		}
+	}
+}
+
+func (s *twoPhaseSearch) phase2Distance(corners, edges, slice uint16) int {
+	t := s.tables
+	return max(
+		int(t.CornerSlicePrune[int(corners)*sliceEdgePermutationSize+int(slice)]),
+		int(t.EdgeSlicePrune[int(edges)*sliceEdgePermutationSize+int(slice)]),
+	)
+}
+
+// phase2 returns true if there is a sequence of exactly togo phase 2 moves
+// which solves the cube.
+func (s *twoPhaseSearch) phase2(corners, edges, slice uint16, n, togo int) bool {
+	if s.stop() {
+		return false
+	}
+	if togo == 0 {
+		return corners == 0 && edges == 0 && slice == 0
+	}
+	t := s.tables
+	for _, m := range phase2Moves {
+		if n > 0 && !canFollow(s.path[n-1], m) {
+			continue
+		}
+		corners2, edges2, slice2 := t.CornerPermutationMove[corners][m], t.UDEdgePermutationMove[edges][m], t.SliceSortedMove[slice][m]
+		if s.phase2Distance(corners2, edges2, slice2) >= togo {
+			continue
+		}
+		s.path[n] = m
+		if s.phase2(corners2, edges2, slice2, n+1, togo-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// canFollow returns false if the face move m turns the same face as the face
+// move last, or turns the opposite face which was already allowed to come
+// first, as these can be written with fewer moves.
+func canFollow(last, m Move) bool {
+	f, g := m%6, last%6
+	return f != g && (f/2 != g/2 || f > g)
+}
+
+func isPhase2Move(m Move) bool {
+	for _, i := range phase2Moves {
+		if i == m {
+			return true
+		}
+	}
+	return false
+}
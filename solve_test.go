@@ -0,0 +1,82 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSolve(t *testing.T) {
+	solution, err := Solve(NewSolvedCube(), SolveOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, solution)
+
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= LeftWide2; m++ {
+		all = append(all, m)
+	}
+	for i := 0; i < 10; i++ {
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 40))
+		solution, err := Solve(cube, SolveOptions{})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(solution), DefaultMaxLength)
+		assert.True(t, cube.Apply(solution).IsSolved(), solution.String())
+		for _, m := range solution {
+			assert.Less(t, m, Move(faceMoveCount))
+		}
+	}
+
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U2 F'"))
+	solution, err = Solve(cube, SolveOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "F U2 R'", solution.String())
+
+	// the solution turns the faces as the cube is held
+	cube = NewSolvedCube().Apply(mustParseAlgorithm(t, "x R U2 F'"))
+	solution, err = Solve(cube, SolveOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "F U2 R'", solution.String())
+
+	cube, _ = NewCubeBuilder(NewSolvedCube()).FlipEdge(SlotUF).AllowIllegal().Build()
+	_, err = Solve(cube, SolveOptions{})
+	assert.ErrorIs(t, err, ErrFlippedEdge)
+}
+
+func TestSolve_Time(t *testing.T) {
+	// the tables take a while to generate the first time
+	DefaultTables()
+	src := rand.NewSource(1)
+	for i := 0; i < 50; i++ {
+		cube := RandomCube(src)
+		start := time.Now()
+		solution, err := Solve(cube, SolveOptions{})
+		elapsed := time.Since(start)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(solution), DefaultMaxLength)
+		assert.Less(t, elapsed, time.Second, "state %d", i)
+	}
+}
+
+func TestSolve_Progress(t *testing.T) {
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "D2 F' U2 B2 R2 F' L2 B' R2 U2 F D' L' B R' U' F2 L2 D' R' F'"))
+	var found []Algorithm
+	solution, err := Solve(cube, SolveOptions{
+		MaxLength: 1,
+		Timeout:   200 * time.Millisecond,
+		Progress: func(a Algorithm) {
+			found = append(found, a)
+		},
+	})
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, found) {
+		assert.Equal(t, found[len(found)-1], solution)
+	}
+	for i, a := range found {
+		assert.True(t, cube.Apply(a).IsSolved(), a.String())
+		if i > 0 {
+			assert.Less(t, len(a), len(found[i-1]))
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package rubiks_cube
+
+// udSymmetryCount is the number of symmetries of the cube which keep the U and
+// D faces on the same axis: the 8 rotations which turn the cube about the UD
+// axis or turn it upside down, each with or without a reflection swapping the
+// R and L faces.
+const udSymmetryCount = 16
+
+// flipSliceClassCount is the number of classes of the Flip and UDSlice
+// coordinates, where each class holds the values the UD symmetries turn into
+// each other.
+const flipSliceClassCount = 64430
+
+// mirrorCornerTable and mirrorEdgeTable are the position each position of a
+// CubieCube moves to when the cube is reflected through the plane between the
+// R and L faces.
+var (
+	mirrorCornerTable = [8]byte{1, 0, 3, 2, 5, 4, 7, 6}
+	mirrorEdgeTable   = [12]byte{2, 1, 0, 3, 6, 5, 4, 7, 9, 8, 11, 10}
+)
+
+// mirror returns the cube reflected through the plane between the R and L
+// faces. The reflection keeps the facelets of the U and D faces on those faces
+// but reverses the direction of every twist.
+func (c CubieCube) mirror() CubieCube {
+	var z CubieCube
+	for i, j := range c.CP {
+		z.CP[mirrorCornerTable[i]] = mirrorCornerTable[j]
+		z.CO[mirrorCornerTable[i]] = (3 - c.CO[i]) % 3
+	}
+	for i, j := range c.EP {
+		z.EP[mirrorEdgeTable[i]] = mirrorEdgeTable[j]
+		z.EO[mirrorEdgeTable[i]] = c.EO[i]
+	}
+	return z
+}
+
+// udSymmetry turns a cube with a rotation keeping the U and D faces on the
+// same axis, then reflects it if mirror is set. Solving the turned cube with
+// face moves is the same as solving the cube with the matching face moves, so
+// both are the same distance from the first phase of Solve.
+type udSymmetry struct {
+	rotation CubieCube
+	mirror   bool
+}
+
+func (s udSymmetry) apply(c CubieCube) CubieCube {
+	c = s.rotation.Inverse().Multiply(c).Multiply(s.rotation)
+	if s.mirror {
+		c = c.mirror()
+	}
+	return c
+}
+
+// udSymmetries returns every UD symmetry, starting with the one which leaves
+// the cube alone.
+func udSymmetries() [udSymmetryCount]udSymmetry {
+	var z [udSymmetryCount]udSymmetry
+	n := 0
+	for _, mirror := range []bool{false, true} {
+		for _, rot := range orientationCubies {
+			// the rotations keeping the UD axis keep the FR, FL, BL and BR
+			// edges between the U and D faces
+			if rot.EP[sliceEdge] < sliceEdge || rot.EP[sliceEdge+1] < sliceEdge || rot.EP[sliceEdge+2] < sliceEdge || rot.EP[sliceEdge+3] < sliceEdge {
+				continue
+			}
+			z[n] = udSymmetry{rotation: rot, mirror: mirror}
+			n++
+		}
+	}
+	return z
+}
+
+// flipSliceSymmetry holds the classes of the Flip and UDSlice coordinates
+// under the UD symmetries. A flip and slice is indexed by UDSlice multiplied
+// by FlipCount plus Flip.
+type flipSliceSymmetry struct {
+	// class and sym hold the class of each flip and slice and a symmetry
+	// which turns it into the first flip and slice of the class, which is
+	// stored in reps.
+	class []uint16
+	sym   []byte
+	reps  []int32
+
+	// stabilizer has a bit set for each symmetry which leaves the first flip
+	// and slice of a class unchanged. The same cube then has an entry for the
+	// twist after each of these symmetries.
+	stabilizer []uint16
+
+	// twist holds the Twist of a cube after each symmetry, as the twist of
+	// the turned cube only depends on the twist of the cube.
+	twist [TwistCount][udSymmetryCount]uint16
+}
+
+func generateFlipSliceSymmetry() *flipSliceSymmetry {
+	syms := udSymmetries()
+	s := &flipSliceSymmetry{
+		class: make([]uint16, FlipCount*UDSliceCount),
+		sym:   make([]byte, FlipCount*UDSliceCount),
+		reps:  make([]int32, 0, flipSliceClassCount),
+
+		stabilizer: make([]uint16, 0, flipSliceClassCount),
+	}
+	var inverse [udSymmetryCount]byte
+	for n := range syms {
+		inverse[n] = byte(inverseSymmetry(syms, n))
+	}
+	seen := make([]bool, FlipCount*UDSliceCount)
+	for i := range seen {
+		if seen[i] {
+			continue
+		}
+		c := NewSolvedCubieCube()
+		c.SetUDSlice(i / FlipCount)
+		c.SetFlip(i % FlipCount)
+		class := uint16(len(s.reps))
+		s.reps = append(s.reps, int32(i))
+		var stabilizer uint16
+		for n, sym := range syms {
+			d := sym.apply(c)
+			j := d.UDSlice()*FlipCount + d.Flip()
+			if j == i {
+				stabilizer |= 1 << n
+			}
+			if !seen[j] {
+				// the inverse of a symmetry is also a symmetry, so turning
+				// j with the inverse of sym reaches i
+				seen[j] = true
+				s.class[j] = class
+				s.sym[j] = inverse[n]
+			}
+		}
+		s.stabilizer = append(s.stabilizer, stabilizer)
+	}
+
+	for i := range s.twist {
+		c := NewSolvedCubieCube()
+		c.SetTwist(i)
+		for n, sym := range syms {
+			s.twist[i][n] = uint16(sym.apply(c).Twist())
+		}
+	}
+	return s
+}
+
+// inverseSymmetry returns the index of the symmetry which undoes syms[n].
+func inverseSymmetry(syms [udSymmetryCount]udSymmetry, n int) int {
+	c := NewSolvedCubieCube()
+	c.SetCornerPermutation(1234)
+	c.SetTwist(567)
+	c.SetSliceSorted(891)
+	c.SetFlip(234)
+	d := syms[n].apply(c)
+	for m, sym := range syms {
+		if sym.apply(d) == c {
+			return m
+		}
+	}
+	panic("symmetry has no inverse")
+}
+
+// index returns the entry of a table indexed by the class of the flip and
+// slice multiplied by TwistCount plus the twist after the symmetry of the
+// class.
+func (s *flipSliceSymmetry) index(twist, flip uint16, udSlice int) int {
+	i := udSlice*FlipCount + int(flip)
+	return int(s.class[i])*TwistCount + int(s.twist[twist][s.sym[i]])
+}
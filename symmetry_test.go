@@ -0,0 +1,52 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCubieCube_mirror(t *testing.T) {
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	// reflecting a face move gives a face move
+	for m := range moves {
+		assert.Contains(t, moves, moves[m].mirror(), Move(m).String())
+	}
+	c := NewSolvedCube().Apply(mustParseAlgorithm(t, "R U2 F' D L2 B")).CubieCube()
+	assert.Equal(t, c, c.mirror().mirror())
+	assert.Equal(t, c.Multiply(moves[Right]).mirror(), c.mirror().Multiply(moves[LeftPrime]))
+}
+
+func TestGenerateFlipSliceSymmetry(t *testing.T) {
+	s := generateFlipSliceSymmetry()
+	assert.Len(t, s.reps, flipSliceClassCount)
+	assert.Equal(t, int32(0), s.reps[0])
+	assert.Equal(t, uint16(0xffff), s.stabilizer[0])
+
+	syms := udSymmetries()
+	src := rand.NewSource(1)
+	for n := 0; n < 20; n++ {
+		r := RandomCube(src).CubieCube()
+		i := r.UDSlice()*FlipCount + r.Flip()
+		d := syms[s.sym[i]].apply(r)
+		assert.Equal(t, int(s.reps[s.class[i]]), d.UDSlice()*FlipCount+d.Flip())
+		for n, sym := range syms {
+			assert.Equal(t, int(s.twist[r.Twist()][n]), sym.apply(r).Twist())
+		}
+
+		// the first flip and slice of the class is left alone by the
+		// symmetries in its stabilizer
+		class := s.class[i]
+		assert.NotZero(t, s.stabilizer[class]&1)
+		for n, sym := range syms {
+			c := NewSolvedCubieCube()
+			c.SetUDSlice(int(s.reps[class]) / FlipCount)
+			c.SetFlip(int(s.reps[class]) % FlipCount)
+			d := sym.apply(c)
+			assert.Equal(t, s.stabilizer[class]&(1<<n) != 0, d.UDSlice()*FlipCount+d.Flip() == int(s.reps[class]))
+		}
+	}
+}
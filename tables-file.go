@@ -0,0 +1,240 @@
+package rubiks_cube
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	ErrTablesFormat   = errors.New("not a tables file")
+	ErrTablesVersion  = errors.New("unsupported tables version")
+	ErrTablesChecksum = errors.New("tables checksum mismatch")
+)
+
+// tablesMagic starts every tables file and tablesVersion must be changed
+// whenever the layout or contents of the tables change.
+const (
+	tablesMagic   = "RCTB"
+	tablesVersion = 3
+)
+
+// TablesEnv is the environment variable holding the file used by
+// DefaultTables.
+const TablesEnv = "RUBIKS_CUBE_TABLES"
+
+var (
+	defaultTablesOnce sync.Once
+	defaultTables     *Tables
+)
+
+// DefaultTables returns the tables shared by the solvers. The first call loads
+// them with LoadTables from the file named by the RUBIKS_CUBE_TABLES
+// environment variable, or generates them if the variable is not set or the
+// file cannot be used. It is safe to call from multiple goroutines.
+func DefaultTables() *Tables {
+	defaultTablesOnce.Do(func() {
+		// LoadTables still returns the tables it generated when the file
+		// cannot be written
+		if path := os.Getenv(TablesEnv); path != "" {
+			defaultTables, _ = LoadTables(path)
+		}
+		if defaultTables == nil {
+			defaultTables = GenerateTables()
+		}
+	})
+	return defaultTables
+}
+
+// LoadTables reads the tables from the file at path. If the file is missing,
+// corrupt or from another version then the tables are generated and written
+// to path so the next load is fast. The generated tables are returned along
+// with the error if the file cannot be written.
+func LoadTables(path string) (*Tables, error) {
+	f, err := os.Open(path)
+	if err == nil {
+		t, err := ReadTables(bufio.NewReader(f))
+		f.Close()
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	t := GenerateTables()
+	return t, writeTablesFile(path, t)
+}
+
+// writeTablesFile writes to a temporary file first so other processes never
+// see part of a file.
+func writeTablesFile(path string, t io.WriterTo) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	_, err = t.WriteTo(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// tablePart is a pointer to a table and the number of entries it must have,
+// which is the number of bytes for a PatternTable.
+type tablePart struct {
+	table any
+	size  int
+}
+
+// parts returns every table in the order they are stored.
+func (t *Tables) parts() []tablePart {
+	return []tablePart{
+		{&t.TwistMove, TwistCount},
+		{&t.FlipMove, FlipCount},
+		{&t.SliceSortedMove, SliceSortedCount},
+		{&t.CornerPermutationMove, CornerPermutationCount},
+		{&t.UDEdgePermutationMove, UDEdgePermutationCount},
+		{&t.TwistSlicePrune, TwistCount * UDSliceCount},
+		{&t.FlipSlicePrune, FlipCount * UDSliceCount},
+		{&t.FlipSliceTwistPrune, (flipSliceClassCount*TwistCount + 1) / 2},
+		{&t.CornerSlicePrune, CornerPermutationCount * sliceEdgePermutationSize},
+		{&t.EdgeSlicePrune, UDEdgePermutationCount * sliceEdgePermutationSize},
+	}
+}
+
+// WriteTo writes the tables in a binary format read by ReadTables. The file
+// starts with "RCTB" and the version, then each table is stored as its length
+// followed by its little endian values and the file ends with the CRC-32 of
+// the tables.
+func (t *Tables) WriteTo(w io.Writer) (int64, error) {
+	return writeParts(w, tablesMagic, tablesVersion, t.parts())
+}
+
+// writeParts writes a file with the magic and version header followed by the
+// tables and their checksum.
+func writeParts(w io.Writer, magic string, version uint32, parts []tablePart) (int64, error) {
+	cw := &countWriter{w: w}
+	if _, err := io.WriteString(cw, magic); err != nil {
+		return cw.n, err
+	}
+	if err := binary.Write(cw, binary.LittleEndian, version); err != nil {
+		return cw.n, err
+	}
+
+	sum := crc32.NewIEEE()
+	body := io.MultiWriter(cw, sum)
+	for _, p := range parts {
+		var err error
+		switch p := p.table.(type) {
+		case *MoveTable:
+			err = writeTable(body, *p)
+		case *PruneTable:
+			err = writeTable(body, *p)
+		case *PatternTable:
+			err = writeTable(body, *p)
+		}
+		if err != nil {
+			return cw.n, err
+		}
+	}
+	err := binary.Write(cw, binary.LittleEndian, sum.Sum32())
+	return cw.n, err
+}
+
+func writeTable[T any](w io.Writer, table []T) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(table))); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, table)
+}
+
+// ReadTables reads tables written by Tables.WriteTo.
+func ReadTables(r io.Reader) (*Tables, error) {
+	t := new(Tables)
+	if err := readParts(r, tablesMagic, tablesVersion, t.parts()); err != nil {
+		return nil, err
+	}
+	// the symmetries are quick to find so they are not stored
+	t.flipSlice = generateFlipSliceSymmetry()
+	return t, nil
+}
+
+// readParts reads a file written by writeParts into the tables.
+func readParts(r io.Reader, magic string, want uint32, parts []tablePart) error {
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, header); err != nil || string(header) != magic {
+		return ErrTablesFormat
+	}
+	var version uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return ErrTablesFormat
+	}
+	if version != want {
+		return fmt.Errorf("%w: %d", ErrTablesVersion, version)
+	}
+
+	sum := crc32.NewIEEE()
+	body := io.TeeReader(r, sum)
+	for _, p := range parts {
+		var err error
+		switch table := p.table.(type) {
+		case *MoveTable:
+			*table, err = readTable[[faceMoveCount]uint16](body, p.size)
+		case *PruneTable:
+			*table, err = readTable[byte](body, p.size)
+		case *PatternTable:
+			*table, err = readTable[byte](body, p.size)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	var checksum uint32
+	if err := binary.Read(r, binary.LittleEndian, &checksum); err != nil {
+		return ErrTablesFormat
+	}
+	if checksum != sum.Sum32() {
+		return ErrTablesChecksum
+	}
+	return nil
+}
+
+// readTable reads a table which must have size entries.
+func readTable[T any](r io.Reader, size int) ([]T, error) {
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, ErrTablesFormat
+	}
+	if int(n) != size {
+		return nil, ErrTablesFormat
+	}
+	table := make([]T, n)
+	if err := binary.Read(r, binary.LittleEndian, table); err != nil {
+		return nil, ErrTablesFormat
+	}
+	return table, nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
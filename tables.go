@@ -0,0 +1,208 @@
+package rubiks_cube
+
+// faceMoveCount is the number of face moves, which are the first moves of the
+// Move type, so a face move is used directly as an index into a move table.
+const faceMoveCount = 18
+
+// phase2Moves are the face moves which keep a cube in the group where every
+// corner and edge is oriented and the FR, FL, BL and BR edges are between the
+// U and D faces.
+var phase2Moves = []Move{Up, UpPrime, Up2, Down, DownPrime, Down2, Right2, Left2, Front2, Back2}
+
+// unknownDistance marks an entry of a pruning table which has not been
+// reached.
+const unknownDistance = 255
+
+// MoveTable maps a coordinate and a face move to the coordinate after the
+// move.
+type MoveTable [][faceMoveCount]uint16
+
+// PruneTable holds the number of face moves needed to solve each combination
+// of two coordinates, which is a lower bound for the moves needed to solve the
+// whole cube.
+type PruneTable []byte
+
+// Tables holds the move and pruning tables used by the solvers. They take
+// several seconds to generate and about 75 MB of memory, so they are normally
+// loaded with DefaultTables or LoadTables.
+//
+// UDEdgePermutationMove is only correct for the phase 2 moves, which keep the
+// FR, FL, BL and BR edges between the U and D faces.
+type Tables struct {
+	TwistMove             MoveTable
+	FlipMove              MoveTable
+	SliceSortedMove       MoveTable
+	CornerPermutationMove MoveTable
+	UDEdgePermutationMove MoveTable
+
+	// TwistSlicePrune and FlipSlicePrune are indexed by Twist or Flip
+	// multiplied by UDSliceCount plus UDSlice.
+	TwistSlicePrune PruneTable
+	FlipSlicePrune  PruneTable
+
+	// FlipSliceTwistPrune holds the number of face moves needed to reach
+	// phase 2 from every Twist, Flip and UDSlice. It is exact up to 9 moves
+	// and every entry further away holds 10 as a lower bound. The flip and
+	// slice are grouped into classes which the symmetries keeping the U and
+	// D faces on their axis turn into each other, so the table is indexed by
+	// the class multiplied by TwistCount plus the twist as the symmetry turns
+	// it. It is much larger than the other tables, see PatternTable.
+	FlipSliceTwistPrune PatternTable
+
+	// CornerSlicePrune and EdgeSlicePrune only use the phase 2 moves and are
+	// indexed by CornerPermutation or UDEdgePermutation multiplied by 24 plus
+	// SliceSorted.
+	CornerSlicePrune PruneTable
+	EdgeSlicePrune   PruneTable
+
+	flipSlice *flipSliceSymmetry
+}
+
+// GenerateTables builds every table from the start.
+func GenerateTables() *Tables {
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+
+	t := &Tables{
+		TwistMove:             generateMoveTable(moves, TwistCount, CubieCube.Twist, (*CubieCube).SetTwist),
+		FlipMove:              generateMoveTable(moves, FlipCount, CubieCube.Flip, (*CubieCube).SetFlip),
+		SliceSortedMove:       generateMoveTable(moves, SliceSortedCount, CubieCube.SliceSorted, (*CubieCube).SetSliceSorted),
+		CornerPermutationMove: generateMoveTable(moves, CornerPermutationCount, CubieCube.CornerPermutation, (*CubieCube).SetCornerPermutation),
+		UDEdgePermutationMove: generateMoveTable(moves, UDEdgePermutationCount, CubieCube.UDEdgePermutation, (*CubieCube).SetUDEdgePermutation),
+	}
+
+	udSliceMove := generateUDSliceMoveTable(t.SliceSortedMove)
+	allMoves := make([]Move, faceMoveCount)
+	for m := range allMoves {
+		allMoves[m] = Move(m)
+	}
+	t.TwistSlicePrune = generatePruneTable(t.TwistMove, udSliceMove, allMoves)
+	t.FlipSlicePrune = generatePruneTable(t.FlipMove, udSliceMove, allMoves)
+	t.flipSlice = generateFlipSliceSymmetry()
+	t.FlipSliceTwistPrune = generateFlipSliceTwistPrune(t, udSliceMove)
+
+	slicePermutationMove := t.SliceSortedMove[:sliceEdgePermutationSize]
+	t.CornerSlicePrune = generatePruneTable(t.CornerPermutationMove, slicePermutationMove, phase2Moves)
+	t.EdgeSlicePrune = generatePruneTable(t.UDEdgePermutationMove, slicePermutationMove, phase2Moves)
+	return t
+}
+
+// generateMoveTable applies each face move to a cube with each value of a
+// coordinate.
+func generateMoveTable(moves [faceMoveCount]CubieCube, count int, get func(CubieCube) int, set func(*CubieCube, int)) MoveTable {
+	table := make(MoveTable, count)
+	for i := range table {
+		c := NewSolvedCubieCube()
+		set(&c, i)
+		for m, move := range moves {
+			table[i][m] = uint16(get(c.Multiply(move)))
+		}
+	}
+	return table
+}
+
+// generateUDSliceMoveTable makes the move table of UDSlice from the move table
+// of SliceSorted by ignoring the order of the edges.
+func generateUDSliceMoveTable(sliceSortedMove MoveTable) MoveTable {
+	table := make(MoveTable, UDSliceCount)
+	for i := range table {
+		for m, j := range sliceSortedMove[i*sliceEdgePermutationSize] {
+			table[i][m] = j / sliceEdgePermutationSize
+		}
+	}
+	return table
+}
+
+// generatePruneTable finds the distance of each combination of the
+// coordinates a and b from the solved state with a breadth first search using
+// only the moves given.
+func generatePruneTable(a, b MoveTable, moves []Move) PruneTable {
+	return generatePruneTableFrom(a, b, moves, []int32{0})
+}
+
+// generatePruneTableFrom finds the distance of each combination of the
+// coordinates a and b from the nearest of the entries in starts.
+func generatePruneTableFrom(a, b MoveTable, moves []Move, starts []int32) PruneTable {
+	n := len(b)
+	table := make(PruneTable, len(a)*n)
+	for i := range table {
+		table[i] = unknownDistance
+	}
+	for _, i := range starts {
+		table[i] = 0
+	}
+	queue := append([]int32(nil), starts...)
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		x, y := int(i)/n, int(i)%n
+		for _, m := range moves {
+			j := int(a[x][m])*n + int(b[y][m])
+			if table[j] == unknownDistance {
+				table[j] = table[i] + 1
+				queue = append(queue, int32(j))
+			}
+		}
+	}
+	return table
+}
+
+// generateFlipSliceTwistPrune finds the distance of every class of the flip
+// and slice with every twist from phase 2, in the same way as
+// generatePatternTable. The moves of the first flip and slice of each class
+// are found once and used for every twist.
+//
+// The last two depths hold most of the table and take much longer to find
+// than the others, so once a quarter of the table is known every entry which
+// is still unknown is given the next depth. This is 10, which is still a lower
+// bound for the few entries at depth 11 and 12.
+func generateFlipSliceTwistPrune(t *Tables, udSliceMove MoveTable) PatternTable {
+	s := t.flipSlice
+	size := flipSliceClassCount * TwistCount
+	table := make(PatternTable, (size+1)/2)
+	for i := range table {
+		table[i] = 0xff
+	}
+	table.set(0, 0)
+	depth := byte(0)
+	for found := 1; found <= size/4; depth++ {
+		for class, rep := range s.reps {
+			flip, udSlice := rep%FlipCount, rep/FlipCount
+			var next [faceMoveCount]struct {
+				class int
+				sym   byte
+			}
+			for m := range next {
+				j := int(udSliceMove[udSlice][m])*FlipCount + int(t.FlipMove[flip][m])
+				next[m].class, next[m].sym = int(s.class[j]), s.sym[j]
+			}
+			for twist := 0; twist < TwistCount; twist++ {
+				i := class*TwistCount + twist
+				if table[i/2] == 0xff || table.Get(i) != depth {
+					continue
+				}
+				for m, n := range next {
+					// the twist is set for each symmetry leaving the first
+					// flip and slice of the class unchanged as these are
+					// all the same cube
+					twist := s.twist[t.TwistMove[twist][m]][n.sym]
+					for sym, bits := 0, s.stabilizer[n.class]; bits != 0; sym, bits = sym+1, bits>>1 {
+						j := n.class*TwistCount + int(s.twist[twist][sym])
+						if bits&1 != 0 && table.Get(j) == unknownPattern {
+							table.set(j, depth+1)
+							found++
+						}
+					}
+				}
+			}
+		}
+	}
+	for i := 0; i < size; i++ {
+		if table.Get(i) == unknownPattern {
+			table.set(i, depth+1)
+		}
+	}
+	return table
+}
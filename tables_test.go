@@ -0,0 +1,119 @@
+package rubiks_cube
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateTables(t *testing.T) {
+	tables := DefaultTables()
+
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 100; i++ {
+		a := randomAlgorithm(r, all, 20)
+		var twist, flip, slice, corners uint16
+		for _, m := range a {
+			twist = tables.TwistMove[twist][m]
+			flip = tables.FlipMove[flip][m]
+			slice = tables.SliceSortedMove[slice][m]
+			corners = tables.CornerPermutationMove[corners][m]
+		}
+		c := NewSolvedCube().Apply(a).CubieCube()
+		assert.Equal(t, c.Twist(), int(twist))
+		assert.Equal(t, c.Flip(), int(flip))
+		assert.Equal(t, c.SliceSorted(), int(slice))
+		assert.Equal(t, c.CornerPermutation(), int(corners))
+
+		// the prune tables are lower bounds
+		s := int(slice) / 24
+		assert.LessOrEqual(t, int(tables.TwistSlicePrune[int(twist)*UDSliceCount+s]), len(a))
+		assert.LessOrEqual(t, int(tables.FlipSlicePrune[int(flip)*UDSliceCount+s]), len(a))
+
+		// the flip, slice and twist table is exact up to 10 moves, so it is
+		// never below the other phase 1 tables
+		d := int(tables.FlipSliceTwistPrune.Get(tables.flipSlice.index(twist, flip, s)))
+		assert.LessOrEqual(t, d, len(a))
+		assert.GreaterOrEqual(t, d, int(tables.TwistSlicePrune[int(twist)*UDSliceCount+s]))
+		assert.GreaterOrEqual(t, d, int(tables.FlipSlicePrune[int(flip)*UDSliceCount+s]))
+
+		// every entry for the same cube has the same distance
+		i := tables.flipSlice.index(twist, flip, s)
+		class, rep := i/TwistCount, i%TwistCount
+		for n := 0; n < udSymmetryCount; n++ {
+			if tables.flipSlice.stabilizer[class]&(1<<n) != 0 {
+				assert.Equal(t, d, int(tables.FlipSliceTwistPrune.Get(class*TwistCount+int(tables.flipSlice.twist[rep][n]))))
+			}
+		}
+	}
+	// short algorithms often reach a flip and slice which some symmetries
+	// leave unchanged
+	var walk func(twist, flip, slice uint16, n int)
+	walk = func(twist, flip, slice uint16, n int) {
+		d := int(tables.FlipSliceTwistPrune.Get(tables.flipSlice.index(twist, flip, int(slice)/24)))
+		if !assert.LessOrEqual(t, d, n) || n == 4 {
+			return
+		}
+		for m := range all {
+			walk(tables.TwistMove[twist][m], tables.FlipMove[flip][m], tables.SliceSortedMove[slice][m], n+1)
+		}
+	}
+	walk(0, 0, 0, 0)
+
+	// the U and D edges are only a coordinate while the cube stays in phase 2
+	a := randomAlgorithm(r, phase2Moves, 30)
+	var edges uint16
+	for _, m := range a {
+		edges = tables.UDEdgePermutationMove[edges][m]
+	}
+	assert.Equal(t, NewSolvedCube().Apply(a).CubieCube().UDEdgePermutation(), int(edges))
+
+	for _, p := range [][]byte{tables.TwistSlicePrune, tables.FlipSlicePrune, tables.CornerSlicePrune, tables.EdgeSlicePrune} {
+		assert.NotContains(t, p, byte(unknownDistance))
+	}
+	assert.Equal(t, byte(0), tables.TwistSlicePrune[0])
+	assert.Equal(t, byte(0), tables.FlipSliceTwistPrune.Get(0))
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "R F")).CubieCube()
+	assert.Equal(t, byte(2), tables.FlipSliceTwistPrune.Get(tables.flipSlice.index(uint16(cube.Twist()), uint16(cube.Flip()), cube.UDSlice())))
+	assert.Equal(t, byte(1), tables.TwistSlicePrune[int(tables.TwistMove[0][Right])*UDSliceCount+int(tables.SliceSortedMove[0][Right])/24])
+}
+
+func TestTables_WriteTo(t *testing.T) {
+	tables := DefaultTables()
+	var buf bytes.Buffer
+	n, err := tables.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	data := buf.Bytes()
+
+	read, err := ReadTables(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, tables, read)
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)/2]++
+	_, err = ReadTables(bytes.NewReader(corrupt))
+	assert.ErrorIs(t, err, ErrTablesChecksum)
+
+	corrupt = append([]byte(nil), data...)
+	corrupt[4] = 99
+	_, err = ReadTables(bytes.NewReader(corrupt))
+	assert.ErrorIs(t, err, ErrTablesVersion)
+
+	_, err = ReadTables(bytes.NewReader(data[:len(data)-10]))
+	assert.ErrorIs(t, err, ErrTablesFormat)
+	_, err = ReadTables(bytes.NewReader([]byte("not tables")))
+	assert.ErrorIs(t, err, ErrTablesFormat)
+
+	path := filepath.Join(t.TempDir(), "tables.bin")
+	assert.NoError(t, writeTablesFile(path, tables))
+	loaded, err := LoadTables(path)
+	assert.NoError(t, err)
+	assert.Equal(t, tables, loaded)
+}
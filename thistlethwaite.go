@@ -0,0 +1,263 @@
+package rubiks_cube
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"sync"
+)
+
+// The face moves which keep a cube in Thistlethwaite's groups G1 and G3. The
+// moves of G2 are the phase 2 moves of the two phase solver.
+var (
+	thistlethwaiteG1Moves = []Move{Up, UpPrime, Up2, Down, DownPrime, Down2, Right, RightPrime, Right2, Left, LeftPrime, Left2, Front2, Back2}
+	halfTurnMoves         = []Move{Up2, Down2, Front2, Back2, Right2, Left2}
+)
+
+// sliceEdgeMask marks the UF, UB, DF and DB edges, which belong between the R
+// and L faces, in the first eight edges of a CubieCube.
+const sliceEdgeMask = 0b10101010
+
+// thistlethwaitePhase moves the cube from one of Thistlethwaite's groups into
+// the next using only the moves of the first group. The table holds the
+// distance to the next group of each coset, found with index. The description
+// is given the faces which U and D are as the cube is held.
+type thistlethwaitePhase struct {
+	stage       string
+	description func(up, down string) string
+	group       Algorithm
+	moves       []Move
+	table       PruneTable
+	index       func(CubieCube) int
+}
+
+var (
+	thistlethwaiteOnce   sync.Once
+	thistlethwaitePhases []thistlethwaitePhase
+)
+
+// SolveThistlethwaite solves the cube with Thistlethwaite's algorithm, which
+// moves the cube through a chain of smaller and smaller groups:
+//
+//	G0 = <U, D, F, B, R, L>
+//	G1 = <U, D, R, L, F2, B2>
+//	G2 = <U, D, R2, L2, F2, B2>
+//	G3 = <U2, D2, R2, L2, F2, B2>
+//	G4 = the solved cube
+//
+// The solution has a segment for each phase, named after the group it
+// reaches, and each phase uses the fewest moves of the group it starts in.
+// The tables are only a few megabytes and are generated the first time they
+// are needed.
+//
+// The algorithm only uses face moves and turns the faces as the cube is held,
+// so the groups are named by the faces as the cube is held too. The cube is
+// checked with RubiksCube.Validate first.
+func SolveThistlethwaite(cube RubiksCube) (Solution, error) {
+	if err := cube.Validate(); err != nil {
+		return nil, err
+	}
+	thistlethwaiteOnce.Do(func() {
+		thistlethwaitePhases = generateThistlethwaitePhases()
+	})
+
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	up, down := cube.Orientation.untranslate(Up).Notation(), cube.Orientation.untranslate(Down).Notation()
+	c := cube.Hold(StandardOrientation).CubieCube()
+	solution := make(Solution, len(thistlethwaitePhases))
+	for i, p := range thistlethwaitePhases {
+		a := Algorithm{}
+		for d := p.table[p.index(c)]; d > 0; d-- {
+			for _, m := range p.moves {
+				if next := c.Multiply(moves[m]); p.table[p.index(next)] == d-1 {
+					a = append(a, m)
+					c = next
+					break
+				}
+			}
+		}
+		group := "the solved cube"
+		if len(p.group) > 0 {
+			group = groupString(cube.Orientation, p.group)
+		}
+		solution[i] = Segment{
+			Stage:       p.stage,
+			Description: fmt.Sprintf("%s, reaching %s = %s", p.description(up, down), p.stage, group),
+			Algorithm:   holdAlgorithm(cube.Orientation, a),
+		}
+	}
+	return solution, nil
+}
+
+// groupString writes the group generated by the moves turning the faces of a
+// cube held in orientation o.
+func groupString(o Orientation, generators Algorithm) string {
+	names := make([]string, len(generators))
+	for i, m := range holdAlgorithm(o, generators) {
+		names[i] = m.Notation()
+	}
+	return "<" + strings.Join(names, ", ") + ">"
+}
+
+// generateThistlethwaitePhases builds the table of each phase. Only the move
+// tables needed for these are made, which are thrown away afterwards.
+func generateThistlethwaitePhases() []thistlethwaitePhase {
+	var moves [faceMoveCount]CubieCube
+	for m := range moves {
+		moves[m] = NewSolvedCube().Move(Move(m)).CubieCube()
+	}
+	allMoves := make([]Move, faceMoveCount)
+	for m := range allMoves {
+		allMoves[m] = Move(m)
+	}
+	twistMove := generateMoveTable(moves, TwistCount, CubieCube.Twist, (*CubieCube).SetTwist)
+	flipMove := generateMoveTable(moves, FlipCount, CubieCube.Flip, (*CubieCube).SetFlip)
+	sliceSortedMove := generateMoveTable(moves, SliceSortedCount, CubieCube.SliceSorted, (*CubieCube).SetSliceSorted)
+	cornerMove := generateMoveTable(moves, CornerPermutationCount, CubieCube.CornerPermutation, (*CubieCube).SetCornerPermutation)
+	udEdgeMove := generateMoveTable(moves, UDEdgePermutationCount, CubieCube.UDEdgePermutation, (*CubieCube).SetUDEdgePermutation)
+
+	// phase 3 needs the positions of the UF, UB, DF and DB edges within the
+	// first eight edges, numbered by the order of the masks
+	var masks []int
+	maskIndex := make([]int, 256)
+	for mask := 0; mask < 256; mask++ {
+		if bits.OnesCount8(uint8(mask)) == 4 {
+			maskIndex[mask] = len(masks)
+			masks = append(masks, mask)
+		}
+	}
+	maskMove := make(MoveTable, len(masks))
+	for i, mask := range masks {
+		c := NewSolvedCubieCube()
+		odd, even := byte(1), byte(0)
+		for j := 0; j < sliceEdge; j++ {
+			if mask&(1<<j) != 0 {
+				c.EP[j], odd = odd, odd+2
+			} else {
+				c.EP[j], even = even, even+2
+			}
+		}
+		for _, m := range phase2Moves {
+			maskMove[i][m] = uint16(maskIndex[edgeMask(c.Multiply(moves[m]))])
+		}
+	}
+
+	// in G3 the corners only reach the permutations made by half turns,
+	// which are the starts of phase 3
+	corners := reachableCoordinates(cornerMove, halfTurnMoves)
+	var starts []int32
+	for _, cp := range corners {
+		starts = append(starts, int32(int(cp)*len(masks)+maskIndex[sliceEdgeMask]))
+	}
+
+	// phase 4 numbers the corners and the U and D edges by the order they are
+	// reached by half turns, then the order of the FR, FL, BL and BR edges
+	cornerIndex := coordinateIndex(corners, CornerPermutationCount)
+	udEdges := reachableCoordinates(udEdgeMove, halfTurnMoves)
+	udEdgeIndex := coordinateIndex(udEdges, UDEdgePermutationCount)
+	cornerHalfMove := make(MoveTable, len(corners))
+	for i, cp := range corners {
+		for _, m := range halfTurnMoves {
+			cornerHalfMove[i][m] = cornerIndex[cornerMove[cp][m]]
+		}
+	}
+	edgeHalfMove := make(MoveTable, len(udEdges)*sliceEdgePermutationSize)
+	for i, ud := range udEdges {
+		for s := 0; s < sliceEdgePermutationSize; s++ {
+			for _, m := range halfTurnMoves {
+				edgeHalfMove[i*sliceEdgePermutationSize+s][m] = udEdgeIndex[udEdgeMove[ud][m]]*sliceEdgePermutationSize + sliceSortedMove[s][m]
+			}
+		}
+	}
+
+	return []thistlethwaitePhase{
+		{
+			stage: "G1",
+			description: func(up, down string) string {
+				return "Orient every edge"
+			},
+			group: Algorithm{Up, Down, Right, Left, Front2, Back2},
+			moves: allMoves,
+			table: generatePruneTable(flipMove, MoveTable{{}}, allMoves),
+			index: CubieCube.Flip,
+		},
+		{
+			stage: "G2",
+			description: func(up, down string) string {
+				return fmt.Sprintf("Orient every corner and move the four edges of the middle layer between %s and %s into that layer", up, down)
+			},
+			group: Algorithm{Up, Down, Right2, Left2, Front2, Back2},
+			moves: thistlethwaiteG1Moves,
+			table: generatePruneTable(twistMove, generateUDSliceMoveTable(sliceSortedMove), thistlethwaiteG1Moves),
+			index: func(c CubieCube) int {
+				return c.Twist()*UDSliceCount + c.UDSlice()
+			},
+		},
+		{
+			stage: "G3",
+			description: func(up, down string) string {
+				return "Move every corner into its tetrad and every edge into its middle layer with even parity"
+			},
+			group: Algorithm{Up2, Down2, Right2, Left2, Front2, Back2},
+			moves: phase2Moves,
+			table: generatePruneTableFrom(cornerMove, maskMove, phase2Moves, starts),
+			index: func(c CubieCube) int {
+				return c.CornerPermutation()*len(masks) + maskIndex[edgeMask(c)]
+			},
+		},
+		{
+			stage: "G4",
+			description: func(up, down string) string {
+				return "Solve the cube with half turns"
+			},
+			group: Algorithm{},
+			moves: halfTurnMoves,
+			table: generatePruneTable(cornerHalfMove, edgeHalfMove, halfTurnMoves),
+			index: func(c CubieCube) int {
+				edges := int(udEdgeIndex[c.UDEdgePermutation()])*sliceEdgePermutationSize + c.SliceSorted()
+				return int(cornerIndex[c.CornerPermutation()])*len(edgeHalfMove) + edges
+			},
+		},
+	}
+}
+
+// edgeMask marks the first eight edge positions holding an edge with an odd
+// number, which are the UF, UB, DF and DB edges.
+func edgeMask(c CubieCube) int {
+	mask := 0
+	for j, e := range c.EP[:sliceEdge] {
+		if e%2 == 1 {
+			mask |= 1 << j
+		}
+	}
+	return mask
+}
+
+// reachableCoordinates returns every value of a coordinate reached from zero
+// using only the moves given, in the order they are reached.
+func reachableCoordinates(table MoveTable, moves []Move) []uint16 {
+	seen := make([]bool, len(table))
+	seen[0] = true
+	z := []uint16{0}
+	for i := 0; i < len(z); i++ {
+		for _, m := range moves {
+			if j := table[z[i]][m]; !seen[j] {
+				seen[j] = true
+				z = append(z, j)
+			}
+		}
+	}
+	return z
+}
+
+// coordinateIndex maps each value in values to its index.
+func coordinateIndex(values []uint16, count int) []uint16 {
+	z := make([]uint16, count)
+	for i, v := range values {
+		z[v] = uint16(i)
+	}
+	return z
+}
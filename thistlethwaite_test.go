@@ -0,0 +1,60 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveThistlethwaite(t *testing.T) {
+	solution, err := SolveThistlethwaite(NewSolvedCube())
+	assert.NoError(t, err)
+	assert.Equal(t, "// G1 (0)\n// G2 (0)\n// G3 (0)\n// G4 (0)", solution.String())
+	assert.Equal(t, "Orient every edge, reaching G1 = <U, D, R, L, F2, B2>", solution[0].Description)
+	assert.Equal(t, "Solve the cube with half turns, reaching G4 = the solved cube", solution[3].Description)
+
+	r := rand.New(rand.NewSource(1))
+	all := make(Algorithm, faceMoveCount)
+	for m := range all {
+		all[m] = Move(m)
+	}
+	for i := 0; i < 20; i++ {
+		cube := NewSolvedCube().Apply(randomAlgorithm(r, all, 40))
+		solution, err := SolveThistlethwaite(cube)
+		assert.NoError(t, err)
+		assert.Len(t, solution, 4)
+
+		// each phase only uses the moves of the group it starts in
+		for _, m := range solution[1].Algorithm {
+			assert.Contains(t, thistlethwaiteG1Moves, m)
+		}
+		for _, m := range solution[2].Algorithm {
+			assert.Contains(t, phase2Moves, m)
+		}
+		for _, m := range solution[3].Algorithm {
+			assert.Contains(t, halfTurnMoves, m)
+		}
+
+		cube = cube.Apply(solution[0].Algorithm)
+		assert.Equal(t, 0, cube.CubieCube().Flip())
+		cube = cube.Apply(solution[1].Algorithm)
+		assert.Equal(t, 0, cube.CubieCube().Twist())
+		assert.Equal(t, 0, cube.CubieCube().UDSlice())
+		cube = cube.Apply(solution[2].Algorithm)
+		assert.Equal(t, sliceEdgeMask, edgeMask(cube.CubieCube()))
+		cube = cube.Apply(solution[3].Algorithm)
+		assert.True(t, cube.IsSolved())
+	}
+
+	// the groups are named by the faces as the cube is held
+	cube := NewSolvedCube().Apply(mustParseAlgorithm(t, "x F"))
+	solution, err = SolveThistlethwaite(cube)
+	assert.NoError(t, err)
+	assert.Equal(t, "// G1 (0)\n// G2 (0)\nF // G3 (1)\nF2 // G4 (1)", solution.String())
+	assert.Equal(t, "Orient every edge, reaching G1 = <B, F, R, L, U2, D2>", solution[0].Description)
+	assert.Equal(t, "Orient every corner and move the four edges of the middle layer between B and F into that layer, reaching G2 = <B, F, R2, L2, U2, D2>", solution[1].Description)
+
+	cube, _ = NewCubeBuilder(NewSolvedCube()).SwapEdges(SlotUF, SlotUB).AllowIllegal().Build()
+	_, err = SolveThistlethwaite(cube)
+	assert.Error(t, err)
+}
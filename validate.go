@@ -0,0 +1,111 @@
+package rubiks_cube
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTwistedCorner = errors.New("twisted corner")
+	ErrFlippedEdge   = errors.New("flipped edge")
+	ErrParity        = errors.New("corner and edge permutation parity do not match")
+)
+
+// ErrDuplicatePiece is returned when the same corner or edge is found more than
+// once on a cube. Piece is either a CornerType or an EdgeType.
+type ErrDuplicatePiece struct {
+	Piece fmt.Stringer
+}
+
+func (e ErrDuplicatePiece) Error() string {
+	return "duplicate piece " + e.Piece.String()
+}
+
+// Validate checks the cube can be solved by turning the faces. Every piece
+// must appear exactly once, the total twist of the corners must be a multiple
+// of three, the total flip of the edges must be even and the parity of the
+// corner permutation must match the parity of the edge permutation.
+//
+// ErrInvalidCubeState is returned if a cubelet or the orientation is not a
+// valid value.
+func (r RubiksCube) Validate() error {
+	if !r.Orientation.Valid() {
+		return ErrInvalidCubeState
+	}
+
+	corners := r.corners()
+	var cornerPerm [8]int
+	var seenCorners [8]bool
+	twist := 0
+	for i, c := range corners {
+		if !c.Valid() {
+			return ErrInvalidCubeState
+		}
+		if seenCorners[c.Piece()] {
+			return ErrDuplicatePiece{c.Piece()}
+		}
+		seenCorners[c.Piece()] = true
+		cornerPerm[i] = cornerHomeTable[c.Piece()]
+		twist += int(c.Twist(cornerSlotPositionTable[i]))
+	}
+
+	edges := r.edges()
+	var edgePerm [12]int
+	var seenEdges [12]bool
+	flip := 0
+	for i, e := range edges {
+		if !e.Valid() {
+			return ErrInvalidCubeState
+		}
+		if seenEdges[e.Piece()] {
+			return ErrDuplicatePiece{e.Piece()}
+		}
+		seenEdges[e.Piece()] = true
+		edgePerm[i] = edgeHomeTable[e.Piece()]
+		flip += int(e.Rotation())
+	}
+
+	if twist%3 != 0 {
+		return ErrTwistedCorner
+	}
+	if flip%2 != 0 {
+		return ErrFlippedEdge
+	}
+	if oddPermutation(cornerPerm[:]) != oddPermutation(edgePerm[:]) {
+		return ErrParity
+	}
+	return nil
+}
+
+// cornerHomeTable and edgeHomeTable are the index of the position of each
+// piece in the solved cube, in the same order as RubiksCube.corners and
+// RubiksCube.edges.
+var cornerHomeTable, edgeHomeTable = func() (corners [8]int, edges [12]int) {
+	solved := NewSolvedCube()
+	for i, c := range solved.corners() {
+		corners[c.Piece()] = i
+	}
+	for i, e := range solved.edges() {
+		edges[e.Piece()] = i
+	}
+	return
+}()
+
+// oddPermutation returns true if the permutation p is made from an odd number
+// of swaps.
+func oddPermutation(p []int) bool {
+	odd := false
+	seen := make([]bool, len(p))
+	for i := range p {
+		if seen[i] {
+			continue
+		}
+		// a cycle of length n is made from n-1 swaps
+		for j := p[i]; j != i; j = p[j] {
+			seen[j] = true
+			odd = !odd
+		}
+		seen[i] = true
+	}
+	return odd
+}
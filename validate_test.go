@@ -0,0 +1,64 @@
+package rubiks_cube
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestRubiksCube_Validate(t *testing.T) {
+	assert.NoError(t, NewSolvedCube().Validate())
+
+	r := rand.New(rand.NewSource(1))
+	var all Algorithm
+	for m := Up; m <= LeftWide2; m++ {
+		all = append(all, m)
+	}
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, NewSolvedCube().Apply(randomAlgorithm(r, all, 30)).Validate())
+	}
+
+	cube := NewSolvedCube()
+	cube.RightCorners[0] = cube.RightCorners[0].Turn(TurnOfFrontBack)
+	assert.ErrorIs(t, cube.Validate(), ErrTwistedCorner)
+
+	cube = NewSolvedCube()
+	cube.MiddleEdges[2] = MakeEdgeCubelet(EdgeYellowRed, EdgeOpposite)
+	assert.ErrorIs(t, cube.Validate(), ErrFlippedEdge)
+
+	cube = NewSolvedCube()
+	cube.RightEdges[0], cube.LeftEdges[0] = cube.LeftEdges[0], cube.RightEdges[0]
+	assert.ErrorIs(t, cube.Validate(), ErrParity)
+
+	cube = NewSolvedCube()
+	cube.RightCorners[0], cube.LeftCorners[0] = cube.LeftCorners[0], cube.RightCorners[0]
+	cube.RightEdges[0], cube.LeftEdges[0] = cube.LeftEdges[0], cube.RightEdges[0]
+	assert.NoError(t, cube.Validate())
+
+	cube = NewSolvedCube()
+	cube.LeftCorners[2] = cube.LeftCorners[3]
+	assert.Equal(t, ErrDuplicatePiece{CornerYellowOrangeBlue}, cube.Validate())
+
+	cube = NewSolvedCube()
+	cube.MiddleEdges[1] = MakeEdgeCubelet(EdgeWhiteOrange, EdgeOpposite)
+	assert.Equal(t, ErrDuplicatePiece{EdgeWhiteOrange}, cube.Validate())
+	assert.EqualError(t, cube.Validate(), "duplicate piece EdgeWhiteOrange")
+
+	cube = NewSolvedCube()
+	cube.RightEdges[3] = 255
+	assert.ErrorIs(t, cube.Validate(), ErrInvalidCubeState)
+}
+
+func TestParseCube_Unsolvable(t *testing.T) {
+	_, err := ParseCube(`   www
+   www
+   wwo
+bbboogwggrrr
+bbbooogggrrr
+bbbooogggrrr
+   yyy
+   yyy
+   yyy
+`)
+	assert.ErrorIs(t, err, ErrTwistedCorner)
+}